@@ -0,0 +1,99 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"regexp/syntax"
+)
+
+// staticAtom is a single character from the static part of a parsed
+// pattern. It is either a literal character, or a character class.
+type staticAtom struct {
+	// ranges holds pairs of runes. Each pair is the lowest and highest
+	// rune (inclusive) in a range of characters that the atom matches.
+	ranges []rune
+}
+
+// parseStaticAtoms turns the regex text held in a patternTypeStatic
+// part back into a list of characters
+//
+// we let the regex package do the work here, so that we understand
+// the text the same way that the regex compiler does
+func parseStaticAtoms(pattern string) ([]staticAtom, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+
+	var retval []staticAtom
+	appendStaticAtoms(&retval, re)
+
+	return retval, nil
+}
+
+// appendStaticAtoms adds the characters from a parsed regex to the
+// given list
+func appendStaticAtoms(atoms *[]staticAtom, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			*atoms = append(*atoms, staticAtom{ranges: []rune{r, r}})
+		}
+	case syntax.OpCharClass:
+		*atoms = append(*atoms, staticAtom{ranges: re.Rune})
+	case syntax.OpAnyCharNotNL:
+		*atoms = append(*atoms, staticAtom{ranges: []rune{0, '\n' - 1, '\n' + 1, '\U0010FFFF'}})
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			appendStaticAtoms(atoms, sub)
+		}
+	}
+}
+
+// isLiteral returns `true` if the atom only matches one character
+func (a staticAtom) isLiteral() bool {
+	return len(a.ranges) == 2 && a.ranges[0] == a.ranges[1]
+}
+
+// contains returns `true` if the atom matches the given character
+func (a staticAtom) contains(r rune) bool {
+	for i := 0; i+1 < len(a.ranges); i += 2 {
+		if r >= a.ranges[i] && r <= a.ranges[i+1] {
+			return true
+		}
+	}
+
+	return false
+}
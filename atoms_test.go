@@ -0,0 +1,114 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStaticAtoms(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern        string
+		expectedResult []staticAtom
+	}{
+		{
+			pattern: "a\\.",
+			expectedResult: []staticAtom{
+				{ranges: []rune{'a', 'a'}},
+				{ranges: []rune{'.', '.'}},
+			},
+		},
+		{
+			pattern: "[\\]a-c]",
+			expectedResult: []staticAtom{
+				{ranges: []rune{']', ']', 'a', 'c'}},
+			},
+		},
+		{
+			pattern: "[[:digit:]]x",
+			expectedResult: []staticAtom{
+				{ranges: []rune{'0', '9'}},
+				{ranges: []rune{'x', 'x'}},
+			},
+		},
+		{
+			pattern: "[^b]",
+			expectedResult: []staticAtom{
+				{ranges: []rune{0, 'a', 'c', '\U0010FFFF'}},
+			},
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, err := parseStaticAtoms(testData.pattern)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err)
+		assert.Equal(t, testData.expectedResult, actualResult, testData.pattern)
+	}
+}
+
+func TestStaticAtomContains(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	atom := staticAtom{ranges: []rune{']', ']', 'a', 'c'}}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.True(t, atom.contains(']'))
+	assert.True(t, atom.contains('b'))
+	assert.False(t, atom.contains('d'))
+	assert.False(t, atom.isLiteral())
+	assert.True(t, staticAtom{ranges: []rune{'x', 'x'}}.isLiteral())
+}
@@ -0,0 +1,354 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// nfa is a non-deterministic finite automaton that accepts the same
+// strings that Glob.Match() does
+//
+// we build it by handing the parsed pattern's regex to the same compiler
+// that the regexp package uses, so that it can never disagree with the
+// regexes that the Match methods run
+type nfa struct {
+	prog *syntax.Prog
+}
+
+// nfaState is the set of instructions that an nfa could be at, after
+// reading some input
+type nfaState []uint32
+
+// newNFA builds the automaton for the given Glob. It returns nil if the
+// Glob's pattern does not compile.
+func newNFA(g *Glob) *nfa {
+	if g.limitErr != nil {
+		return nil
+	}
+
+	// we leave out the anchors, and treat reaching the end of the
+	// input as the only way to match
+	return newNFAFromRegex(buildRegex(g.patternParts, GlobShortestMatch))
+}
+
+// newUnionNFA builds a single automaton that accepts any string that at
+// least one of the given Globs matches. Globs whose patterns do not
+// compile are left out.
+func newUnionNFA(globs []*Glob) *nfa {
+	var rawRegexes []string
+	for _, g := range globs {
+		if newNFA(g) != nil {
+			rawRegexes = append(rawRegexes, "(?:"+buildRegex(g.patternParts, GlobShortestMatch)+")")
+		}
+	}
+	if len(rawRegexes) == 0 {
+		return newEmptyNFA()
+	}
+
+	return newNFAFromRegex(strings.Join(rawRegexes, "|"))
+}
+
+// newNFAFromRegex builds the automaton for an unanchored regex. It
+// returns nil if the regex does not compile.
+func newNFAFromRegex(rawRegex string) *nfa {
+	re, err := syntax.Parse(rawRegex, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return nil
+	}
+
+	return &nfa{prog: prog}
+}
+
+// start returns the state that the automaton is in before reading
+// any input
+func (n *nfa) start() nfaState {
+	return n.closure([]uint32{uint32(n.prog.Start)}, true)
+}
+
+// closure follows every instruction that doesn't consume input, and
+// returns the set of instructions that do (plus any match instructions)
+func (n *nfa) closure(pcs []uint32, atStart bool) nfaState {
+	seen := map[uint32]bool{}
+	var retval nfaState
+
+	stack := append([]uint32(nil), pcs...)
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[pc] {
+			continue
+		}
+		seen[pc] = true
+
+		inst := &n.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, inst.Out, inst.Arg)
+		case syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, inst.Out)
+		case syntax.InstEmptyWidth:
+			// our regexes don't use any anchors, apart from these
+			if syntax.EmptyOp(inst.Arg) == syntax.EmptyBeginText && atStart {
+				stack = append(stack, inst.Out)
+			}
+		case syntax.InstFail:
+			// nothing to do
+		default:
+			retval = append(retval, pc)
+		}
+	}
+
+	sort.Slice(retval, func(i, j int) bool { return retval[i] < retval[j] })
+	return retval
+}
+
+// step returns the state that the automaton moves to after reading r
+func (n *nfa) step(state nfaState, r rune) nfaState {
+	var next []uint32
+	for _, pc := range state {
+		inst := &n.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstRune, syntax.InstRune1:
+			if inst.MatchRune(r) {
+				next = append(next, inst.Out)
+			}
+		case syntax.InstRuneAny:
+			next = append(next, inst.Out)
+		case syntax.InstRuneAnyNotNL:
+			if r != '\n' {
+				next = append(next, inst.Out)
+			}
+		}
+	}
+
+	return n.closure(next, false)
+}
+
+// accepts returns `true` if the automaton matches when the input ends
+// in this state
+func (n *nfa) accepts(state nfaState) bool {
+	for _, pc := range state {
+		if n.prog.Inst[pc].Op == syntax.InstMatch {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addBoundaries adds the first rune of each range of input that the
+// state treats differently
+func (n *nfa) addBoundaries(state nfaState, boundaries map[rune]bool) {
+	for _, pc := range state {
+		inst := &n.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstRune:
+			if len(inst.Rune) == 1 {
+				boundaries[inst.Rune[0]] = true
+				boundaries[inst.Rune[0]+1] = true
+				break
+			}
+			for i := 0; i+1 < len(inst.Rune); i += 2 {
+				boundaries[inst.Rune[i]] = true
+				boundaries[inst.Rune[i+1]+1] = true
+			}
+		case syntax.InstRune1:
+			boundaries[inst.Rune[0]] = true
+			boundaries[inst.Rune[0]+1] = true
+		case syntax.InstRuneAnyNotNL:
+			boundaries['\n'] = true
+			boundaries['\n'+1] = true
+		}
+	}
+}
+
+// key returns a string that identifies the state, for use in maps
+func (s nfaState) key() string {
+	buf := strings.Builder{}
+	for _, pc := range s {
+		buf.WriteString(strconv.FormatUint(uint64(pc), 10))
+		buf.WriteRune(',')
+	}
+
+	return buf.String()
+}
+
+// representativeRunes returns one rune from each range of input that
+// the two states treat the same way
+//
+// we prefer printable ASCII characters, so that any witness strings are
+// easy to read
+func representativeRunes(a *nfa, stateA nfaState, b *nfa, stateB nfaState) []rune {
+	boundaries := map[rune]bool{0: true, utf8.MaxRune + 1: true}
+	a.addBoundaries(stateA, boundaries)
+	b.addBoundaries(stateB, boundaries)
+
+	points := make([]rune, 0, len(boundaries))
+	for r := range boundaries {
+		points = append(points, r)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i] < points[j] })
+
+	var retval []rune
+	for i := 0; i+1 < len(points); i++ {
+		r, ok := pickRune(points[i], points[i+1]-1)
+		if ok {
+			retval = append(retval, r)
+		}
+	}
+
+	return retval
+}
+
+// pickRune returns a rune between lo and hi (inclusive) that can
+// appear in a Go string
+func pickRune(lo, hi rune) (rune, bool) {
+	for _, r := range printableChars {
+		if r >= lo && r <= hi {
+			return r, true
+		}
+	}
+	if lo < 0xD800 || lo > 0xDFFF {
+		return lo, true
+	}
+	if hi > 0xDFFF {
+		return 0xE000, true
+	}
+
+	// the range is nothing but surrogates
+	return 0, false
+}
+
+// productSearch walks the product of two automata, one input character
+// at a time, looking for a string where both automata are in states
+// that satisfy found()
+//
+// when alive() returns `false` for a pair of states, we stop walking
+// past them
+//
+// it returns the shortest such string, and `true` if it found one
+func productSearch(
+	a, b *nfa,
+	alive func(stateA, stateB nfaState) bool,
+	found func(stateA, stateB nfaState) bool,
+) (string, bool) {
+	type node struct {
+		stateA nfaState
+		stateB nfaState
+		input  []rune
+	}
+
+	queue := []node{{stateA: a.start(), stateB: b.start()}}
+	seen := map[string]bool{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		key := current.stateA.key() + "|" + current.stateB.key()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if found(current.stateA, current.stateB) {
+			return string(current.input), true
+		}
+		if !alive(current.stateA, current.stateB) {
+			continue
+		}
+
+		for _, r := range representativeRunes(a, current.stateA, b, current.stateB) {
+			input := make([]rune, len(current.input), len(current.input)+1)
+			copy(input, current.input)
+			queue = append(queue, node{
+				stateA: a.step(current.stateA, r),
+				stateB: b.step(current.stateB, r),
+				input:  append(input, r),
+			})
+		}
+	}
+
+	return "", false
+}
+
+// newEmptyNFA returns an automaton that matches nothing at all
+func newEmptyNFA() *nfa {
+	prog := &syntax.Prog{
+		Inst: []syntax.Inst{{Op: syntax.InstFail}},
+	}
+
+	return &nfa{prog: prog}
+}
+
+// matchesNothing returns `true` if the automaton does not accept any
+// strings at all
+func (n *nfa) matchesNothing() bool {
+	_, found := productSearch(
+		n,
+		newEmptyNFA(),
+		func(state, _ nfaState) bool {
+			return len(state) > 0
+		},
+		func(state, _ nfaState) bool {
+			return n.accepts(state)
+		},
+	)
+
+	return !found
+}
+
+// findCounterexample looks for the shortest string that b accepts, but
+// a does not
+func findCounterexample(a, b *nfa) (string, bool) {
+	return productSearch(
+		a,
+		b,
+		func(stateA, stateB nfaState) bool {
+			return len(stateB) > 0
+		},
+		func(stateA, stateB nfaState) bool {
+			return b.accepts(stateB) && !a.accepts(stateA)
+		},
+	)
+}
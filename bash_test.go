@@ -0,0 +1,446 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/ganbarodigital/go_glob/globtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// bashCase is a single pattern and input to run through bash
+type bashCase struct {
+	pattern string
+	input   string
+}
+
+// bashResult holds what each of bash's pattern operators returns
+// for a single bashCase
+type bashResult struct {
+	match          bool
+	shortestPrefix string
+	longestPrefix  string
+	shortestSuffix string
+	longestSuffix  string
+}
+
+// bashCorpus is the list of cases that we compare against bash
+var bashCorpus = []bashCase{
+	// empty strings
+	{"", ""},
+	{"*", ""},
+	{"?", ""},
+	{"", "abc"},
+
+	// static strings
+	{"0123456789", "0123456789"},
+	{"1234567890", "0123456789"},
+	{"0123456789", "012345"},
+	{"012345", "0123456789"},
+	{"5678", "0123456789"},
+
+	// single wildcards
+	{"0?23456789", "0123456789"},
+	{"0?23?5?7?9", "0123456789"},
+	{"??????????", "0123456789"},
+	{"1?34567890", "0123456789"},
+	{"56?89", "0123456789"},
+	{"6?8", "0123456789"},
+
+	// variable length wildcards
+	{"0*9", "0123456789"},
+	{"*9", "0123456789"},
+	{"012*", "0123456789"},
+	{"012*34567890", "01234567890"},
+	{"0*2*4*6*8*", "0123456789"},
+	{"0*5", "01115012225"},
+	{"0*5", "011115022225"},
+	{"4*9", "0123456789"},
+	{"*/", "path/to/folder"},
+	{"/*", "path/to/folder"},
+	{"*/*", "path/to/folder"},
+	{"*.*", "archive.tar.gz"},
+	{".*", "archive.tar.gz"},
+	{"*.", "archive.tar.gz"},
+	{"**", "abc"},
+	{"a*b*c", "aXbYcZabc"},
+
+	// character sets
+	{"[12340]1234567890", "01234567890"},
+	{"[0-9][0-9][0-9]", "01234567890"},
+	{"[!b].go", "a.go"},
+	{"[!b].go", "b.go"},
+	{"[^b].go", "a.go"},
+	{"[^b].go", "b.go"},
+	{"[*?]", "*"},
+	{"[*?]", "?"},
+	{"[*?]", "a"},
+	{"[]a]", "]"},
+	{"[]a]", "a"},
+	{"[!]a]", "b"},
+	{"[[:digit:]]", "7"},
+	{"[[:alpha:]]*", "abc123"},
+	{"*[[:digit:]]", "abc123"},
+	{"[a-c]*[x-z]", "bananaz"},
+	{"[-a]", "-"},
+	{"[a-]", "-"},
+
+	// characters that are special in regexes, but not in globs
+	{"foo (*)", "foo (copy)"},
+	{"a|b", "a|b"},
+	{"a|b", "a"},
+	{"^$", "^$"},
+	{"a.c", "abc"},
+	{"a.c", "a.c"},
+	{"a+", "a+"},
+	{"a+", "aa"},
+	{"{a,b}", "{a,b}"},
+	{"{a,b}", "a"},
+
+	// escapes
+	{"\\a", "a"},
+	{"\\*", "*"},
+	{"\\*", "abc"},
+	{"\\?", "?"},
+	{"\\[a]", "[a]"},
+	{"abc\\\\", "abc\\"},
+	{"a\\\\*", "a\\bcb"},
+	{"a\\*", "a*bc"},
+	{"a\\**", "a*bc"},
+}
+
+// bashQuote turns s into a single-quoted bash string
+func bashQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// buildBashScript creates a bash script that runs every case through
+// `case` and the four prefix / suffix removal operators
+//
+// the script writes five NUL-terminated fields for each case
+func buildBashScript(cases []bashCase) string {
+	script := strings.Builder{}
+
+	// we want the same pattern syntax on every machine
+	script.WriteString("shopt -u extglob nocasematch nocaseglob\n")
+	script.WriteString("export LC_ALL=C\n")
+
+	for _, c := range cases {
+		fmt.Fprintf(&script, "p=%s\n", bashQuote(c.pattern))
+		fmt.Fprintf(&script, "v=%s\n", bashQuote(c.input))
+		script.WriteString("case \"$v\" in $p) m=1 ;; *) m=0 ;; esac\n")
+		script.WriteString("printf '%s\\0' \"$m\" \"${v#$p}\" \"${v##$p}\" \"${v%$p}\" \"${v%%$p}\"\n")
+	}
+
+	return script.String()
+}
+
+// runBash runs every case through bash, and returns what bash did
+// with each one
+func runBash(t *testing.T, cases []bashCase) []bashResult {
+	bashPath, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash is not installed")
+	}
+
+	cmd := exec.Command(bashPath, "--norc", "--noprofile")
+	cmd.Stdin = strings.NewReader(buildBashScript(cases))
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("bash failed: %s", err)
+	}
+
+	fields := bytes.Split(output, []byte{0})
+	if len(fields) != len(cases)*5+1 {
+		t.Fatalf("expected %d fields from bash, got %d", len(cases)*5, len(fields)-1)
+	}
+
+	retval := make([]bashResult, len(cases))
+	for i := range cases {
+		f := fields[i*5 : i*5+5]
+		retval[i] = bashResult{
+			match:          string(f[0]) == "1",
+			shortestPrefix: string(f[1]),
+			longestPrefix:  string(f[2]),
+			shortestSuffix: string(f[3]),
+			longestSuffix:  string(f[4]),
+		}
+	}
+
+	return retval
+}
+
+// runGlob does the same as bash's pattern operators, using each of
+// our Match methods
+func runGlob(c bashCase) (bashResult, error) {
+	g := NewGlob(c.pattern)
+	retval := bashResult{
+		shortestPrefix: c.input,
+		longestPrefix:  c.input,
+		shortestSuffix: c.input,
+		longestSuffix:  c.input,
+	}
+
+	var err error
+	retval.match, err = g.Match(c.input)
+	if err != nil {
+		return retval, err
+	}
+
+	prefixMatchers := []struct {
+		matcher func(string) (int, bool, error)
+		result  *string
+	}{
+		{g.MatchShortestPrefix, &retval.shortestPrefix},
+		{g.MatchLongestPrefix, &retval.longestPrefix},
+	}
+	for _, m := range prefixMatchers {
+		pos, success, err := m.matcher(c.input)
+		if err != nil {
+			return retval, err
+		}
+		if success {
+			*m.result = c.input[pos:]
+		}
+	}
+
+	suffixMatchers := []struct {
+		matcher func(string) (int, bool, error)
+		result  *string
+	}{
+		{g.MatchShortestSuffix, &retval.shortestSuffix},
+		{g.MatchLongestSuffix, &retval.longestSuffix},
+	}
+	for _, m := range suffixMatchers {
+		pos, success, err := m.matcher(c.input)
+		if err != nil {
+			return retval, err
+		}
+		if success {
+			*m.result = c.input[:pos]
+		}
+	}
+
+	return retval, nil
+}
+
+// knownBashDifference returns a reason if we already know that glob
+// gives a different answer to bash for this operator and case
+//
+// these are differences that the rest of our test suite depends on;
+// the harness reports them, but does not fail on them
+func knownBashDifference(c bashCase, operator string) string {
+	// buildRegex treats a '*' at the end of the pattern as longest match,
+	// even in MatchShortestPrefix()
+	if operator == "${v#p}" && endsWithMultiMatch(c.pattern) {
+		return "MatchShortestPrefix() treats a trailing '*' as longest match"
+	}
+
+	return ""
+}
+
+// endsWithMultiMatch returns `true` if the last thing in the pattern is
+// a '*' wildcard
+//
+// we ask the parser, so that an escaped '*' doesn't count, and a '*'
+// after an escaped '\' does
+func endsWithMultiMatch(pattern string) bool {
+	parts := parsePattern(pattern)
+	return len(parts) > 0 && parts[len(parts)-1].patternType == patternTypeMultiMatch
+}
+
+// diffBashResults returns a readable description of any differences
+// between what bash did and what we did, and of any known differences
+// that we skipped over
+func diffBashResults(c bashCase, expected, actual bashResult) (string, string) {
+	rows := []struct {
+		operator string
+		bash     string
+		glob     string
+	}{
+		{"case", fmt.Sprint(expected.match), fmt.Sprint(actual.match)},
+		{"${v#p}", fmt.Sprintf("%q", expected.shortestPrefix), fmt.Sprintf("%q", actual.shortestPrefix)},
+		{"${v##p}", fmt.Sprintf("%q", expected.longestPrefix), fmt.Sprintf("%q", actual.longestPrefix)},
+		{"${v%p}", fmt.Sprintf("%q", expected.shortestSuffix), fmt.Sprintf("%q", actual.shortestSuffix)},
+		{"${v%%p}", fmt.Sprintf("%q", expected.longestSuffix), fmt.Sprintf("%q", actual.longestSuffix)},
+	}
+
+	diff := strings.Builder{}
+	known := strings.Builder{}
+	for _, row := range rows {
+		if row.bash == row.glob {
+			continue
+		}
+
+		buf := &diff
+		reason := knownBashDifference(c, row.operator)
+		if reason != "" {
+			buf = &known
+		}
+		fmt.Fprintf(buf, "  %-8s bash: %-20s glob: %s\n", row.operator, row.bash, row.glob)
+		if reason != "" {
+			fmt.Fprintf(buf, "           known difference: %s\n", reason)
+		}
+	}
+
+	header := fmt.Sprintf("p=%q v=%q\n", c.pattern, c.input)
+	var retDiff, retKnown string
+	if diff.Len() > 0 {
+		retDiff = header + diff.String()
+	}
+	if known.Len() > 0 {
+		retKnown = header + known.String()
+	}
+
+	return retDiff, retKnown
+}
+
+// corpusBashCases returns each pattern and input from our conformance
+// corpus, so that cases added to the corpus are also checked against
+// bash
+//
+// it skips cases that expect an error, and inputs that bash can't hold
+// in a variable
+func corpusBashCases() []bashCase {
+	var retval []bashCase
+
+	seen := map[bashCase]bool{}
+	for _, c := range bashCorpus {
+		seen[c] = true
+	}
+
+	for _, c := range globtest.Corpus() {
+		if c.Error || strings.ContainsRune(c.Input, 0) {
+			continue
+		}
+
+		bc := bashCase{pattern: c.Pattern, input: c.Input}
+		if seen[bc] {
+			continue
+		}
+		seen[bc] = true
+		retval = append(retval, bc)
+	}
+
+	return retval
+}
+
+// checkAgainstBash runs each case through bash and through our Match
+// methods, and reports any differences
+func checkAgainstBash(t *testing.T, cases []bashCase) {
+	// ----------------------------------------------------------------
+	// setup your test
+
+	expectedResults := runBash(t, cases)
+
+	for i, c := range cases {
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, err := runGlob(c)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		if err != nil {
+			t.Errorf("p=%q v=%q\n  glob error: %s", c.pattern, c.input, err)
+			continue
+		}
+		diff, known := diffBashResults(c, expectedResults[i], actualResult)
+		if diff != "" {
+			t.Errorf("glob does not agree with bash:\n%s", diff)
+		}
+		if known != "" {
+			t.Logf("glob does not agree with bash:\n%s", known)
+		}
+	}
+}
+
+func TestMatchMethodsAgreeWithBash(t *testing.T) {
+	t.Parallel()
+
+	checkAgainstBash(t, bashCorpus)
+}
+
+func TestCorpusAgreesWithBash(t *testing.T) {
+	t.Parallel()
+
+	cases := corpusBashCases()
+	if len(cases) == 0 {
+		t.Fatal("expected cases from globtest.Corpus()")
+	}
+
+	checkAgainstBash(t, cases)
+}
+
+func TestKnownBashDifferenceOnlyCoversATrailingWildcard(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern        string
+		operator       string
+		expectedResult bool
+	}{
+		{"a*", "${v#p}", true},
+		{"a\\\\*", "${v#p}", true},
+		{"a\\**", "${v#p}", true},
+		{"a\\*", "${v#p}", false},
+		{"a*b", "${v#p}", false},
+		{"a[*]", "${v#p}", false},
+		{"a*", "${v##p}", false},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		c := bashCase{pattern: testData.pattern}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := knownBashDifference(c, testData.operator) != ""
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedResult, actualResult, testData)
+	}
+}
@@ -0,0 +1,363 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// canonicalToken is one piece of a canonical pattern. Wildcards are
+// kept apart from everything else, so that runs of wildcards can be
+// rewritten.
+type canonicalToken struct {
+	// wildcard is the patternType of a wildcard, or patternTypeNone
+	wildcard int
+
+	// text is the canonical text of anything that isn't a wildcard
+	text string
+}
+
+// Canonical returns the Glob's pattern, rewritten so that patterns
+// that are written differently but mean the same thing come out the
+// same. It uses the same syntax as the original pattern.
+//
+// It:
+//
+//   - removes escapes that aren't needed, e.g. `a\b` becomes `ab`
+//   - sorts and merges the members of bracket expressions, e.g. `[cba]`
+//     becomes `[a-c]`, and `[x]` becomes `x`
+//   - collapses runs of wildcards, e.g. `**` becomes `*` and `?*?`
+//     becomes `*??` in UNIX shell syntax
+//   - removes duplicate choices in `{s1,s2}`, e.g. `{b,a,b}` becomes
+//     `{b,a}`, and `{x,x}` becomes `x`
+//   - rewrites numeric ranges as `{lo..hi}`
+//
+// It never changes whether the pattern ends with a `*`, because a `*`
+// at the end of the pattern always matches as much as it can. It never
+// reorders the choices in `{s1,s2}`, because the prefix and suffix
+// methods try them in order.
+//
+// Two Globs with the same syntax and the same Canonical() form return
+// the same results from every Match method. Some Globs that match the
+// same strings still have different Canonical() forms; use
+// `Equivalent()` if you need an exact answer for Match().
+//
+// If the pattern does not compile, or breaks one of the Glob's limits,
+// it returns the original pattern.
+func (g *Glob) Canonical() string {
+	if g.limitErr != nil {
+		return g.pattern
+	}
+
+	tokens, err := g.canonicalTokens(g.patternParts)
+	if err != nil {
+		return g.pattern
+	}
+
+	return g.renderCanonicalTokens(tokens)
+}
+
+// Hash returns a hash of the Glob's syntax and its Canonical() form.
+// Use it as a cache key. It is the same from one run of your program to
+// the next.
+func (g *Glob) Hash() uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(g.syntax))
+	h.Write([]byte{0})
+	h.Write([]byte(g.Canonical()))
+
+	return h.Sum64()
+}
+
+// canonicalTokens turns parsed patterns into canonical tokens
+func (g *Glob) canonicalTokens(parts []parsedPattern) ([]canonicalToken, error) {
+	var retval []canonicalToken
+
+	for _, part := range parts {
+		switch part.patternType {
+		case patternTypeStatic:
+			atoms, err := parseStaticAtoms(part.pattern)
+			if err != nil {
+				return nil, err
+			}
+			for _, atom := range atoms {
+				retval = append(retval, canonicalToken{text: g.canonicalAtom(atom)})
+			}
+		case patternTypeGlobStar:
+			// '/**/' is a different wildcard to '**'
+			if part.pattern == "/**/" {
+				retval = append(retval, canonicalToken{text: part.pattern})
+			} else {
+				retval = append(retval, canonicalToken{wildcard: part.patternType})
+			}
+		case patternTypeNumericRange:
+			lo, hi, _ := parseNumericRange(part.pattern)
+			retval = append(retval, canonicalToken{text: fmt.Sprintf("{%d..%d}", lo, hi)})
+		case patternTypeAlternatives:
+			tokens, err := g.canonicalAlternatives(part.alternatives)
+			if err != nil {
+				return nil, err
+			}
+			retval = append(retval, tokens...)
+		default:
+			retval = append(retval, canonicalToken{wildcard: part.patternType})
+		}
+	}
+
+	return retval, nil
+}
+
+// canonicalAlternatives turns the choices of a {s1,s2} expression into
+// canonical tokens
+func (g *Glob) canonicalAlternatives(alternatives [][]parsedPattern) ([]canonicalToken, error) {
+	var choices []string
+	var firstTokens []canonicalToken
+	seen := map[string]bool{}
+
+	for _, alternative := range alternatives {
+		tokens, err := g.canonicalTokens(alternative)
+		if err != nil {
+			return nil, err
+		}
+
+		choice := g.renderCanonicalTokens(tokens)
+		if seen[choice] {
+			continue
+		}
+		seen[choice] = true
+
+		if len(choices) == 0 {
+			firstTokens = tokens
+		}
+		choices = append(choices, choice)
+	}
+
+	// special case - once the duplicates are gone, a single choice
+	// doesn't need the braces at all
+	//
+	// we can only take the braces away if the choice starts and ends
+	// with normal characters; otherwise, its wildcards could run into
+	// the wildcards around it, and be read back differently
+	if len(choices) == 1 {
+		if len(firstTokens) > 0 &&
+			firstTokens[0].wildcard == patternTypeNone &&
+			firstTokens[len(firstTokens)-1].wildcard == patternTypeNone {
+			return firstTokens, nil
+		}
+		choices = append(choices, choices[0])
+	}
+
+	return []canonicalToken{{text: "{" + strings.Join(choices, ",") + "}"}}, nil
+}
+
+// renderCanonicalTokens turns canonical tokens back into a pattern
+func (g *Glob) renderCanonicalTokens(tokens []canonicalToken) string {
+	retval := strings.Builder{}
+	escapeSlash := false
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].wildcard == patternTypeNone {
+			if escapeSlash && strings.HasPrefix(tokens[i].text, "/") {
+				retval.WriteRune('\\')
+			}
+			retval.WriteString(tokens[i].text)
+			escapeSlash = false
+			continue
+		}
+
+		// find the end of this run of wildcards
+		end := i
+		for end < len(tokens) && tokens[end].wildcard != patternTypeNone {
+			end++
+		}
+
+		run := renderWildcardRun(tokens[i:end], end == len(tokens))
+
+		// special case - in EditorConfig syntax, a '**' between two
+		// slashes would turn into a '/**/', which also matches a
+		// single '/'
+		escapeSlash = run == "**" && strings.HasSuffix(retval.String(), "/")
+
+		retval.WriteString(run)
+		i = end - 1
+	}
+
+	return retval.String()
+}
+
+// renderWildcardRun turns a run of wildcards into its canonical form.
+// atEnd is `true` if nothing comes after the run.
+//
+// every '?' and '*' between two '**' matches the same set of
+// characters, so all that matters is whether there is a '*', and how
+// many '?' there are. We can't move them past a '**', because
+// they can't match a '/', and a '**' can.
+//
+// a '*' in the middle of the pattern matches as little as it can when
+// the prefix and suffix methods use GlobShortestMatch, but a '*' at
+// the very end always matches as much as it can. We have to keep the
+// last wildcard of the pattern the same kind, so that those methods
+// give the same results.
+func renderWildcardRun(run []canonicalToken, atEnd bool) string {
+	retval := strings.Builder{}
+	singles := 0
+	hasMulti := false
+	lastWasGlobStar := false
+
+	// we normally write the '*' first, so that it can't run into a
+	// '**' that comes after it
+	flush := func(multiLast bool) {
+		if hasMulti && !multiLast {
+			retval.WriteRune('*')
+		}
+		retval.WriteString(strings.Repeat("?", singles))
+		if hasMulti && multiLast {
+			retval.WriteRune('*')
+		}
+		singles = 0
+		hasMulti = false
+	}
+
+	for _, token := range run {
+		switch token.wildcard {
+		case patternTypeSingleMatch, patternTypeSegmentSingleMatch:
+			singles++
+			lastWasGlobStar = false
+		case patternTypeMultiMatch, patternTypeSegmentMultiMatch:
+			hasMulti = true
+			lastWasGlobStar = false
+		case patternTypeGlobStar:
+			flush(false)
+			if !lastWasGlobStar {
+				retval.WriteString("**")
+			}
+			lastWasGlobStar = true
+		}
+	}
+
+	// nothing comes after the last part of the run, so its '*' can go
+	// last without running into a '**'
+	last := run[len(run)-1].wildcard
+	flush(atEnd && (last == patternTypeMultiMatch || last == patternTypeSegmentMultiMatch))
+
+	return retval.String()
+}
+
+// canonicalAtom returns the canonical text for a single character from
+// a static part of the pattern
+func (g *Glob) canonicalAtom(atom staticAtom) string {
+	if atom.isLiteral() {
+		return g.escapeLiteral(atom.ranges[0])
+	}
+
+	ranges := atom.ranges
+	retval := strings.Builder{}
+	retval.WriteRune('[')
+
+	// a class that goes all the way to the end of unicode came from a
+	// negated bracket expression, and it is shorter to write it that
+	// way again
+	if ranges[len(ranges)-1] == unicode.MaxRune {
+		complement := complementRanges(ranges)
+		if len(complement) > 0 {
+			retval.WriteRune('!')
+			ranges = complement
+		}
+	}
+
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		retval.WriteString(g.escapeClassMember(lo))
+		switch {
+		case hi == lo+1:
+			retval.WriteString(g.escapeClassMember(hi))
+		case hi > lo:
+			retval.WriteRune('-')
+			retval.WriteString(g.escapeClassMember(hi))
+		}
+	}
+
+	retval.WriteRune(']')
+	return retval.String()
+}
+
+// complementRanges returns the runes that are not in the given ranges
+func complementRanges(ranges []rune) []rune {
+	var retval []rune
+
+	next := rune(0)
+	for i := 0; i+1 < len(ranges); i += 2 {
+		if ranges[i] > next {
+			retval = append(retval, next, ranges[i]-1)
+		}
+		next = ranges[i+1] + 1
+	}
+	if next <= unicode.MaxRune {
+		retval = append(retval, next, unicode.MaxRune)
+	}
+
+	return retval
+}
+
+// escapeLiteral returns the text for a literal character, escaped if
+// the Glob's syntax would treat it as special
+func (g *Glob) escapeLiteral(r rune) string {
+	special := "\\*?["
+	if g.syntax == editorConfigSyntax {
+		special += "{},"
+	}
+
+	if strings.ContainsRune(special, r) {
+		return "\\" + string(r)
+	}
+	return string(r)
+}
+
+// escapeClassMember returns the text for a character inside a bracket
+// expression, escaped if the Glob's syntax would treat it as special
+func (g *Glob) escapeClassMember(r rune) string {
+	special := "\\]-[!^"
+	if g.syntax == editorConfigSyntax {
+		special += "{},"
+	}
+
+	if strings.ContainsRune(special, r) {
+		return "\\" + string(r)
+	}
+	return string(r)
+}
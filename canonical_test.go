@@ -0,0 +1,355 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"testing"
+
+	"github.com/ganbarodigital/go_glob/globtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type canonicalTestDataStruct struct {
+	pattern        string
+	options        []func(*Glob)
+	expectedResult string
+}
+
+var canonicalTestDataSet = []canonicalTestDataStruct{
+	{pattern: "src/*.go", expectedResult: "src/*.go"},
+	{pattern: "**.go", expectedResult: "*.go"},
+	{pattern: "a***b", expectedResult: "a*b"},
+	{pattern: "?*?*", expectedResult: "??*"},
+	{pattern: "*?*?", expectedResult: "*??"},
+	{pattern: "a?*b", expectedResult: "a*?b"},
+	{pattern: "a*?", expectedResult: "a*?"},
+	{pattern: "a?*", expectedResult: "a?*"},
+	{pattern: "a\\b\\.c", expectedResult: "ab.c"},
+	{pattern: "\\*\\?\\[\\\\", expectedResult: "\\*\\?\\[\\\\"},
+	{pattern: "[cba]", expectedResult: "[a-c]"},
+	{pattern: "[ba]", expectedResult: "[ab]"},
+	{pattern: "[x]", expectedResult: "x"},
+	{pattern: "[[:digit:]]", expectedResult: "[0-9]"},
+	{pattern: "[^ba]", expectedResult: "[!ab]"},
+	{pattern: "[!]a-]", expectedResult: "[!\\-\\]a]"},
+	{pattern: "12345[", expectedResult: "12345["},
+	{pattern: "", expectedResult: ""},
+	{
+		pattern:        "{b,a,b}.txt",
+		options:        []func(*Glob){EditorConfigSyntax},
+		expectedResult: "{b,a}.txt",
+	},
+	{
+		pattern:        "{xa,xa}b",
+		options:        []func(*Glob){EditorConfigSyntax},
+		expectedResult: "xab",
+	},
+	{
+		// the choice ends with a wildcard, so we keep the braces
+		pattern:        "{a*,a*}*",
+		options:        []func(*Glob){EditorConfigSyntax},
+		expectedResult: "{a*,a*}*",
+	},
+	{
+		pattern:        "{\\,,x}",
+		options:        []func(*Glob){EditorConfigSyntax},
+		expectedResult: "{\\,,x}",
+	},
+	{
+		pattern:        "v{+05..-3}",
+		options:        []func(*Glob){EditorConfigSyntax},
+		expectedResult: "v{-3..5}",
+	},
+	{
+		pattern:        "a/**/b/**/*.go",
+		options:        []func(*Glob){EditorConfigSyntax},
+		expectedResult: "a/**/b/**/*.go",
+	},
+	{
+		pattern:        "*?**?*****",
+		options:        []func(*Glob){EditorConfigSyntax},
+		expectedResult: "*?**?***",
+	},
+	{
+		pattern:        "a/*{**,**}/b",
+		options:        []func(*Glob){EditorConfigSyntax},
+		expectedResult: "a/*{**,**}/b",
+	},
+	{
+		// without the escape, this would be a '/**/'
+		pattern:        "a/**\\/b",
+		options:        []func(*Glob){EditorConfigSyntax},
+		expectedResult: "a/**\\/b",
+	},
+	{
+		pattern:        "\\{a\\}",
+		options:        []func(*Glob){EditorConfigSyntax},
+		expectedResult: "\\{a\\}",
+	},
+	{
+		// this pattern breaks a limit
+		pattern:        "a\\b",
+		options:        []func(*Glob){MaxPatternLength(2)},
+		expectedResult: "a\\b",
+	},
+}
+
+func TestGlobCanonical(t *testing.T) {
+	t.Parallel()
+
+	for _, testData := range canonicalTestDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern, testData.options...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := g.Canonical()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedResult, actualResult, testData.pattern)
+	}
+}
+
+func TestGlobCanonicalMatchesTheSameStrings(t *testing.T) {
+	t.Parallel()
+
+	for _, testData := range canonicalTestDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern, testData.options...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		canonical := NewGlob(g.Canonical(), testData.options...)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.True(t, Equivalent(g, canonical), testData.pattern)
+		assert.Equal(t, canonical.Canonical(), g.Canonical(), testData.pattern)
+	}
+}
+
+func TestGlobHashIsTheSameForEquivalentSpellings(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	first := NewGlob("src/[ba]**.go")
+	second := NewGlob("src/[ab]*\\.go")
+	other := NewGlob("src/[ab]*.c")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	firstHash := first.Hash()
+	secondHash := second.Hash()
+	otherHash := other.Hash()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, firstHash, secondHash)
+	assert.NotEqual(t, firstHash, otherHash)
+}
+
+func TestGlobHashDependsOnSyntax(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	shell := NewGlob("src/*.go")
+	editorConfig := NewGlob("src/*.go", EditorConfigSyntax)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	shellHash := shell.Hash()
+	editorConfigHash := editorConfig.Hash()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, shell.Canonical(), editorConfig.Canonical())
+	assert.NotEqual(t, shellHash, editorConfigHash)
+}
+
+func TestGlobHashIsStable(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	g := NewGlob("src/*.go")
+
+	// FNV-1a of "shell\x00src/*.go"
+	expectedResult := uint64(0xfb6d576bd79574ee)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := g.Hash()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestGlobCanonicalMatchesTheSameStringsAcrossTheCorpus(t *testing.T) {
+	t.Parallel()
+
+	for _, testData := range globtest.Corpus() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.Pattern)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		canonical := NewGlob(g.Canonical())
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.True(t, Equivalent(g, canonical), testData.Pattern)
+	}
+}
+
+func TestGlobsWithTheSameHashGiveTheSameResultsFromEveryMatchMethod(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		patterns []string
+		options  []func(*Glob)
+	}{
+		{
+			patterns: []string{
+				"a?*", "a*?", "a**?", "a?**", "a*?*", "a?*?", "a*??",
+				"a??*", "a?*b", "a*?b", "a**b", "a*b", "*?", "?*",
+				"*?*?", "?*?*", "??*", "*??", "[ba]*", "[ab]**",
+				"a\\*", "a*",
+			},
+		},
+		{
+			patterns: []string{
+				"a?*", "a*?", "a**", "a*", "a**?*", "a*?**",
+				"{ab,a}*", "{a,ab}*", "{ab,a,ab}*", "{ab,ab}*",
+				"a/**/?*", "a/**/*?", "a/**?", "a/?**",
+			},
+			options: []func(*Glob){EditorConfigSyntax},
+		},
+	}
+
+	inputs := []string{
+		"", "a", "ab", "abcd", "abab", "ba", "bab", "a/b", "a/b/cd",
+		"xa/b", "a\nb", "aab",
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		byHash := map[uint64][]*Glob{}
+		for _, pattern := range testData.patterns {
+			g := NewGlob(pattern, testData.options...)
+			byHash[g.Hash()] = append(byHash[g.Hash()], g)
+		}
+
+		for _, globs := range byHash {
+			first := globs[0]
+			for _, other := range globs[1:] {
+				for _, input := range inputs {
+					// ----------------------------------------------------------------
+					// perform the change
+
+					firstMatch, _ := first.Match(input)
+					otherMatch, _ := other.Match(input)
+					firstSP, firstSPOk, _ := first.MatchShortestPrefix(input)
+					otherSP, otherSPOk, _ := other.MatchShortestPrefix(input)
+					firstLP, firstLPOk, _ := first.MatchLongestPrefix(input)
+					otherLP, otherLPOk, _ := other.MatchLongestPrefix(input)
+					firstSS, firstSSOk, _ := first.MatchShortestSuffix(input)
+					otherSS, otherSSOk, _ := other.MatchShortestSuffix(input)
+					firstLS, firstLSOk, _ := first.MatchLongestSuffix(input)
+					otherLS, otherLSOk, _ := other.MatchLongestSuffix(input)
+
+					// ----------------------------------------------------------------
+					// test the results
+
+					msg := first.Pattern() + " vs " + other.Pattern() + " on " + input
+					assert.Equal(t, firstMatch, otherMatch, "Match: "+msg)
+					assert.Equal(t, []interface{}{firstSP, firstSPOk}, []interface{}{otherSP, otherSPOk}, "MatchShortestPrefix: "+msg)
+					assert.Equal(t, []interface{}{firstLP, firstLPOk}, []interface{}{otherLP, otherLPOk}, "MatchLongestPrefix: "+msg)
+					assert.Equal(t, []interface{}{firstSS, firstSSOk}, []interface{}{otherSS, otherSSOk}, "MatchShortestSuffix: "+msg)
+					assert.Equal(t, []interface{}{firstLS, firstLSOk}, []interface{}{otherLS, otherLSOk}, "MatchLongestSuffix: "+msg)
+				}
+			}
+		}
+	}
+}
+
+func TestGlobHashTellsTrailingWildcardsApart(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// a trailing '*' matches as much as it can, so these two give
+	// different results from MatchShortestPrefix()
+	first := NewGlob("a?*")
+	second := NewGlob("a*?")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	firstHash := first.Hash()
+	secondHash := second.Hash()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotEqual(t, firstHash, secondHash)
+}
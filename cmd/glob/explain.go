@@ -0,0 +1,106 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	glob "github.com/ganbarodigital/go_glob"
+)
+
+// runExplain implements `glob explain`
+func runExplain(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("explain", "explain PATTERN [INPUT]", stderr)
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return exitMatch
+	}
+	if err != nil {
+		return exitError
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return exitError
+	}
+
+	g := glob.NewGlob(fs.Arg(0))
+
+	// we print the explanation even if the pattern is broken, because
+	// that's when it is most useful
+	explanation, err := g.Explain()
+	fmt.Fprint(stdout, explanation)
+	if err != nil {
+		return fail(stderr, "explain", err)
+	}
+	if fs.NArg() < 2 {
+		return exitMatch
+	}
+
+	result, err := g.ExplainMatch(fs.Arg(1))
+	if err != nil {
+		return fail(stderr, "explain", err)
+	}
+
+	fmt.Fprintf(stdout, "input: %q\n", result.Input)
+	if result.Matched {
+		fmt.Fprintln(stdout, "  matched")
+		return exitMatch
+	}
+
+	matched := result.Input[:result.Offset]
+	if result.Node < len(explanation.Nodes) {
+		node := explanation.Nodes[result.Node]
+		fmt.Fprintf(
+			stdout,
+			"  no match: node %d (%s %s) failed after %q\n",
+			result.Node,
+			node.Type,
+			node.Pattern,
+			matched,
+		)
+	} else {
+		fmt.Fprintf(
+			stdout,
+			"  no match: every node matched %q, leaving %q unmatched\n",
+			matched,
+			result.Input[result.Offset:],
+		)
+	}
+
+	return exitNoMatch
+}
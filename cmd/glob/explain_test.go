@@ -0,0 +1,124 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainPrintsParsedPattern(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	status, stdout, stderr := runForTest("", "explain", "src/*.go")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, exitMatch, status)
+	assert.Contains(t, stdout, "pattern: src/*.go\n")
+	assert.Contains(t, stdout, "1: multi          *\n")
+	assert.Contains(t, stdout, "regex:  ^src/.*\\.go\n")
+	assert.NotContains(t, stdout, "input:")
+	assert.Empty(t, stderr)
+}
+
+func TestExplainShowsWhereMatchingFailed(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		input          string
+		expectedStatus int
+		expectedOutput string
+	}{
+		{
+			"src/main.go",
+			exitMatch,
+			"input: \"src/main.go\"\n  matched\n",
+		},
+		{
+			"lib/main.go",
+			exitNoMatch,
+			"input: \"lib/main.go\"\n  no match: node 0 (static src/) failed after \"\"\n",
+		},
+		{
+			"src/main.go.orig",
+			exitNoMatch,
+			"input: \"src/main.go.orig\"\n  no match: every node matched \"src/main.go\", leaving \".orig\" unmatched\n",
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		status, stdout, _ := runForTest("", "explain", "src/*.go", testData.input)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedStatus, status, testData.input)
+		assert.Contains(t, stdout, testData.expectedOutput, testData.input)
+	}
+}
+
+func TestExplainPrintsExplanationForInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	status, stdout, stderr := runForTest("", "explain", "12345[", "12345")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, exitError, status)
+	assert.Contains(t, stdout, "regex:  ^12345[$\n")
+	assert.Contains(t, stderr, "missing closing ]")
+}
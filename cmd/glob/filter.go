@@ -0,0 +1,109 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// runFilter implements `glob filter`
+func runFilter(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("filter", "filter [mode] [-v] [-c] [-0] PATTERN", stderr)
+	modes := addModeFlags(fs)
+	invert := fs.Bool("v", false, "print the lines that do not match")
+	count := fs.Bool("c", false, "print how many lines match, instead of the lines")
+	nulSeparated := fs.Bool("0", false, "lines end with a NUL byte instead of a newline")
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return exitMatch
+	}
+	if err != nil {
+		return exitError
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	m, err := modes.mode()
+	if err != nil {
+		return fail(stderr, "filter", err)
+	}
+	g, err := newGlob(fs.Arg(0))
+	if err != nil {
+		return fail(stderr, "filter", err)
+	}
+
+	sep := recordSeparator(*nulSeparated)
+	out := bufio.NewWriter(stdout)
+	defer out.Flush()
+
+	matches := 0
+	err = eachRecord(stdin, sep, func(record string) error {
+		_, success, err := m.match(g, record)
+		if err != nil {
+			return err
+		}
+		if success == *invert {
+			return nil
+		}
+
+		matches++
+		if *count {
+			return nil
+		}
+		_, err = out.WriteString(record)
+		if err == nil {
+			err = out.WriteByte(sep)
+		}
+		return err
+	})
+	if err != nil {
+		return fail(stderr, "filter", err)
+	}
+
+	if *count {
+		fmt.Fprintln(out, matches)
+	}
+	if matches == 0 {
+		return exitNoMatch
+	}
+
+	return exitMatch
+}
@@ -0,0 +1,113 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	glob "github.com/ganbarodigital/go_glob"
+)
+
+// runLint implements `glob lint`
+func runLint(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("lint", "lint [--last-match-wins] FILE", stderr)
+	lastMatchWins := fs.Bool("last-match-wins", false, "the last matching rule wins, as in .gitignore files")
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return exitMatch
+	}
+	if err != nil {
+		return exitError
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	filename := fs.Arg(0)
+	var content []byte
+	if filename == "-" {
+		content, err = io.ReadAll(stdin)
+	} else {
+		content, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return fail(stderr, "lint", err)
+	}
+
+	order := glob.FirstMatchWins
+	if *lastMatchWins {
+		order = glob.LastMatchWins
+	}
+
+	diags := glob.Lint(parseRules(content), order)
+	for _, diag := range diags {
+		fmt.Fprintf(stdout, "%s: %s\n", filename, diag)
+	}
+	if len(diags) > 0 {
+		return exitNoMatch
+	}
+
+	return exitMatch
+}
+
+// parseRules turns a rules file into a list of rules. Each line holds
+// one pattern. A pattern that starts with '!' is a deny rule; use '\!'
+// for a pattern that starts with a literal '!'. Blank lines, and lines
+// that start with '#', are skipped.
+func parseRules(content []byte) []glob.Rule {
+	var retval []glob.Rule
+
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.TrimSpace(line) == "" || line[0] == '#' {
+			continue
+		}
+
+		deny := line[0] == '!'
+		if deny {
+			line = line[1:]
+		}
+
+		retval = append(retval, glob.Rule{Glob: glob.NewGlob(line), Line: i + 1, Deny: deny})
+	}
+
+	return retval
+}
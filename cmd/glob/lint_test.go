@@ -0,0 +1,166 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const exampleRules = `# routing rules
+src/*
+src/*.go
+
+*.md
+*.md
+12345[
+`
+
+func TestLintReportsProblemsWithLineNumbers(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	filename := filepath.Join(t.TempDir(), "rules.txt")
+	err := os.WriteFile(filename, []byte(exampleRules), 0644)
+	assert.Nil(t, err)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	status, stdout, stderr := runForTest("", "lint", filename)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, exitNoMatch, status)
+	assert.Empty(t, stderr)
+	assert.Equal(
+		t,
+		filename+": line 3: 'src/*.go' can never match; line 2 ('src/*') matches everything it does\n"+
+			filename+": line 6: '*.md' is a duplicate of line 5 ('*.md')\n"+
+			filename+": line 7: '12345[' is invalid: bad or unsupported glob pattern '12345[': error parsing regexp: missing closing ]: `[$`\n",
+		stdout,
+	)
+}
+
+func TestLintSupportsLastMatchWins(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rules := "debug.log\n*.log\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	status, stdout, _ := runForTest(rules, "lint", "--last-match-wins", "-")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, exitNoMatch, status)
+	assert.Equal(t, "-: line 1: 'debug.log' is always overridden by line 2 ('*.log')\n", stdout)
+}
+
+func TestLintSupportsDenyRules(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rules := "!secrets/*\nsrc/*\n!secrets/*.key\n\\!important\n!\\!important\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	status, stdout, _ := runForTest(rules, "lint", "-")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, exitNoMatch, status)
+	assert.Equal(
+		t,
+		"-: line 3: '!secrets/*.key' can never match; line 1 ('!secrets/*') matches everything it does\n"+
+			"-: line 5: '!\\!important' is a duplicate of line 4 ('\\!important')\n",
+		stdout,
+	)
+}
+
+func TestLintExitsWithZeroForCleanRules(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rules := "src/*.go\nsrc/*\n"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	status, stdout, _ := runForTest(rules, "lint", "-")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, exitMatch, status)
+	assert.Empty(t, stdout)
+}
+
+func TestLintReturnsErrorWhenFileMissing(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	filename := filepath.Join(t.TempDir(), "missing.txt")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	status, _, stderr := runForTest("", "lint", filename)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, exitError, status)
+	assert.Contains(t, stderr, "glob lint:")
+}
@@ -0,0 +1,146 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Command glob matches, filters and trims strings using UNIX shell
+// glob patterns. It uses the go_glob package, so your shell scripts
+// get exactly the same results as your Go code.
+//
+// Usage:
+//
+//	glob match [mode] PATTERN [STRING...]
+//	glob filter [mode] [-v] [-c] [-0] PATTERN
+//	glob trim mode [-0] PATTERN
+//	glob explain PATTERN [INPUT]
+//	glob lint [--last-match-wins] FILE
+//
+// mode is one of --shortest-prefix, --longest-prefix,
+// --shortest-suffix or --longest-suffix. Without one, the pattern has
+// to match the whole string.
+//
+// `glob match` exits with status 0 if every STRING matches, and 1 if
+// any of them don't. `glob filter` exits with status 0 if it found any
+// matching lines, and 1 if it didn't. `glob explain` exits with status
+// 1 if INPUT doesn't match. `glob lint` exits with status 1 if it
+// found any problems. All commands exit with status 2
+// if there was an error.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	exitMatch   = 0
+	exitNoMatch = 1
+	exitError   = 2
+)
+
+// command is a single subcommand, such as `glob match`
+type command struct {
+	name     string
+	synopsis string
+	run      func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+}
+
+// commands holds every subcommand, in the order that we list them in
+// our usage message
+var commands = []command{
+	{
+		name:     "match",
+		synopsis: "exit 0 if every STRING matches PATTERN",
+		run:      runMatch,
+	},
+	{
+		name:     "filter",
+		synopsis: "print the lines from stdin that match PATTERN",
+		run:      runFilter,
+	},
+	{
+		name:     "trim",
+		synopsis: "remove the part of each line from stdin that matches PATTERN",
+		run:      runTrim,
+	},
+	{
+		name:     "explain",
+		synopsis: "show how PATTERN is parsed, and why INPUT does or doesn't match",
+		run:      runExplain,
+	},
+	{
+		name:     "lint",
+		synopsis: "report rules in FILE that can never decide the outcome",
+		run:      runLint,
+	},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run works out which subcommand to call, and calls it
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitError
+	}
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return exitMatch
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdin, stdout, stderr)
+		}
+	}
+
+	fmt.Fprintf(stderr, "glob: unknown command %q\n", args[0])
+	usage(stderr)
+	return exitError
+}
+
+// usage writes our top-level help message
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: glob COMMAND [options] PATTERN ...")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.synopsis)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "run 'glob COMMAND -h' for the options of each command")
+}
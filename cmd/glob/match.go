@@ -0,0 +1,94 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package main
+
+import (
+	"flag"
+	"io"
+)
+
+// runMatch implements `glob match`
+func runMatch(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("match", "match [mode] PATTERN [STRING...]", stderr)
+	modes := addModeFlags(fs)
+
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return exitMatch
+	}
+	if err != nil {
+		return exitError
+	}
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	m, err := modes.mode()
+	if err != nil {
+		return fail(stderr, "match", err)
+	}
+	g, err := newGlob(fs.Arg(0))
+	if err != nil {
+		return fail(stderr, "match", err)
+	}
+
+	// without any STRINGs, we read them from stdin instead
+	var inputs []string
+	if fs.NArg() > 1 {
+		inputs = fs.Args()[1:]
+	} else {
+		err = eachRecord(stdin, '\n', func(record string) error {
+			inputs = append(inputs, record)
+			return nil
+		})
+		if err != nil {
+			return fail(stderr, "match", err)
+		}
+	}
+
+	retval := exitMatch
+	for _, input := range inputs {
+		_, success, err := m.match(g, input)
+		if err != nil {
+			return fail(stderr, "match", err)
+		}
+		if !success {
+			retval = exitNoMatch
+		}
+	}
+
+	return retval
+}
@@ -0,0 +1,84 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchReportsResultThroughExitStatus(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		args           []string
+		stdin          string
+		expectedStatus int
+	}{
+		{[]string{"*.go", "main.go"}, "", exitMatch},
+		{[]string{"*.go", "main.go", "match.go"}, "", exitMatch},
+		{[]string{"*.go", "main.go", "README.md"}, "", exitNoMatch},
+		{[]string{"*.go", "main.go.orig"}, "", exitNoMatch},
+		{[]string{"--longest-prefix", "*/", "path/to/folder"}, "", exitMatch},
+		{[]string{"--shortest-suffix", ".*", "main.go"}, "", exitMatch},
+		{[]string{"--shortest-suffix", ".*", "Makefile"}, "", exitNoMatch},
+		{[]string{"[!.]*"}, "main.go\nREADME.md\n", exitMatch},
+		{[]string{"[!.]*"}, "main.go\n.gitignore\n", exitNoMatch},
+		{[]string{"--", "-*", "-v"}, "", exitMatch},
+		{[]string{"12345[", "12345["}, "", exitError},
+		{[]string{"--shortest-prefix", "--longest-prefix", "*", "x"}, "", exitError},
+		{[]string{"--no-such-flag", "*", "x"}, "", exitError},
+		{[]string{}, "", exitError},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		args := append([]string{"match"}, testData.args...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		status, stdout, _ := runForTest(testData.stdin, args...)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedStatus, status, testData.args)
+		assert.Empty(t, stdout, testData.args)
+	}
+}
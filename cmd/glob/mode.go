@@ -0,0 +1,194 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	glob "github.com/ganbarodigital/go_glob"
+)
+
+// mode says which of the Glob.Match* methods to use
+type mode int
+
+const (
+	modeWhole mode = iota
+	modeShortestPrefix
+	modeLongestPrefix
+	modeShortestSuffix
+	modeLongestSuffix
+)
+
+// modeFlags holds the command-line flags that pick a mode
+type modeFlags struct {
+	shortestPrefix bool
+	longestPrefix  bool
+	shortestSuffix bool
+	longestSuffix  bool
+}
+
+// newFlagSet creates the flag.FlagSet for a subcommand
+func newFlagSet(name, synopsis string, stderr io.Writer) *flag.FlagSet {
+	retval := flag.NewFlagSet(name, flag.ContinueOnError)
+	retval.SetOutput(stderr)
+	retval.Usage = func() {
+		fmt.Fprintf(stderr, "usage: glob %s\n\noptions:\n", synopsis)
+		retval.PrintDefaults()
+	}
+
+	return retval
+}
+
+// addModeFlags adds the flags that pick a mode to the given FlagSet
+func addModeFlags(fs *flag.FlagSet) *modeFlags {
+	retval := modeFlags{}
+	fs.BoolVar(&retval.shortestPrefix, "shortest-prefix", false, "match the shortest prefix, like ${var#pattern}")
+	fs.BoolVar(&retval.longestPrefix, "longest-prefix", false, "match the longest prefix, like ${var##pattern}")
+	fs.BoolVar(&retval.shortestSuffix, "shortest-suffix", false, "match the shortest suffix, like ${var%pattern}")
+	fs.BoolVar(&retval.longestSuffix, "longest-suffix", false, "match the longest suffix, like ${var%%pattern}")
+
+	return &retval
+}
+
+// mode returns the mode picked on the command line. It returns an
+// error if more than one mode was picked.
+func (f *modeFlags) mode() (mode, error) {
+	retval := modeWhole
+	count := 0
+
+	for m, picked := range map[mode]bool{
+		modeShortestPrefix: f.shortestPrefix,
+		modeLongestPrefix:  f.longestPrefix,
+		modeShortestSuffix: f.shortestSuffix,
+		modeLongestSuffix:  f.longestSuffix,
+	} {
+		if picked {
+			retval = m
+			count++
+		}
+	}
+
+	if count > 1 {
+		return modeWhole, errors.New("only one of --shortest-prefix, --longest-prefix, --shortest-suffix and --longest-suffix can be used")
+	}
+
+	return retval, nil
+}
+
+// match calls the Glob.Match* method for this mode.
+//
+// For prefix modes, the returned position is the end of the prefix.
+// For suffix modes, it is the start of the suffix. For modeWhole, it
+// is always the length of input.
+func (m mode) match(g *glob.Glob, input string) (int, bool, error) {
+	switch m {
+	case modeShortestPrefix:
+		return g.MatchShortestPrefix(input)
+	case modeLongestPrefix:
+		return g.MatchLongestPrefix(input)
+	case modeShortestSuffix:
+		return g.MatchShortestSuffix(input)
+	case modeLongestSuffix:
+		return g.MatchLongestSuffix(input)
+	}
+
+	success, err := g.Match(input)
+	return len(input), success, err
+}
+
+// trim removes the matched part of input, using the position returned
+// by match()
+func (m mode) trim(input string, pos int) string {
+	switch m {
+	case modeShortestSuffix, modeLongestSuffix:
+		return input[:pos]
+	}
+
+	return input[pos:]
+}
+
+// newGlob creates the Glob for the given pattern, and makes sure that
+// it compiles
+func newGlob(pattern string) (*glob.Glob, error) {
+	retval := glob.NewGlob(pattern)
+	_, err := retval.Match("")
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// eachRecord calls fn for each record in r. Records end with sep; the
+// last record doesn't have to.
+func eachRecord(r io.Reader, sep byte, fn func(record string) error) error {
+	br := bufio.NewReader(r)
+	for {
+		record, err := br.ReadString(sep)
+		if len(record) > 0 {
+			fnErr := fn(strings.TrimSuffix(record, string(sep)))
+			if fnErr != nil {
+				return fnErr
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// recordSeparator returns the byte that ends each record
+func recordSeparator(nulSeparated bool) byte {
+	if nulSeparated {
+		return 0
+	}
+
+	return '\n'
+}
+
+// fail writes the error to stderr, and returns our error exit status
+func fail(stderr io.Writer, name string, err error) int {
+	fmt.Fprintf(stderr, "glob %s: %s\n", name, err)
+	return exitError
+}
@@ -0,0 +1,123 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModeFlagsRejectsMoreThanOneMode(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	modes := addModeFlags(fs)
+	err := fs.Parse([]string{"--shortest-prefix", "--longest-suffix"})
+	assert.Nil(t, err)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err = modes.mode()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Error(t, err)
+}
+
+func TestModeMatchUsesEachMatchMethod(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		flag           string
+		expectedPos    int
+		expectedResult string
+	}{
+		{"", 14, ""},
+		{"--shortest-prefix", 5, "to/folder"},
+		{"--longest-prefix", 8, "folder"},
+		{"--shortest-suffix", 7, "path/to"},
+		{"--longest-suffix", 4, "path"},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		modes := addModeFlags(fs)
+		args := []string{}
+		if testData.flag != "" {
+			args = append(args, testData.flag)
+		}
+		err := fs.Parse(args)
+		assert.Nil(t, err)
+
+		m, err := modes.mode()
+		assert.Nil(t, err)
+
+		// suffix modes need the separator at the other end
+		pattern := "*/"
+		if m == modeShortestSuffix || m == modeLongestSuffix {
+			pattern = "/*"
+		}
+		if m == modeWhole {
+			pattern = "*"
+		}
+		g, err := newGlob(pattern)
+		assert.Nil(t, err)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		pos, success, err := m.match(g, "path/to/folder")
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, testData.flag)
+		assert.True(t, success, testData.flag)
+		assert.Equal(t, testData.expectedPos, pos, testData.flag)
+		assert.Equal(t, testData.expectedResult, m.trim("path/to/folder", pos), testData.flag)
+	}
+}
@@ -0,0 +1,113 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrimRemovesMatchingPartOfEachLine(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		args           []string
+		stdin          string
+		expectedStatus int
+		expectedOutput string
+	}{
+		{
+			[]string{"--shortest-prefix", "*/"},
+			"path/to/folder\nfolder\n",
+			exitMatch,
+			"to/folder\nfolder\n",
+		},
+		{
+			[]string{"--longest-prefix", "*/"},
+			"path/to/folder\nfolder\n",
+			exitMatch,
+			"folder\nfolder\n",
+		},
+		{
+			[]string{"--shortest-suffix", "/*"},
+			"path/to/folder\nfolder\n",
+			exitMatch,
+			"path/to\nfolder\n",
+		},
+		{
+			[]string{"--longest-suffix", "/*"},
+			"path/to/folder\nfolder\n",
+			exitMatch,
+			"path\nfolder\n",
+		},
+		{
+			[]string{"-0", "--longest-suffix", ".*"},
+			"a file.tar.gz\x00b\nfile.txt",
+			exitMatch,
+			"a file\x00b\nfile\x00",
+		},
+		{
+			[]string{"*/"},
+			"path/to/folder\n",
+			exitError,
+			"",
+		},
+		{
+			[]string{"--longest-prefix", "12345["},
+			"path/to/folder\n",
+			exitError,
+			"",
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		args := append([]string{"trim"}, testData.args...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		status, stdout, _ := runForTest(testData.stdin, args...)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedStatus, status, testData.args)
+		assert.Equal(t, testData.expectedOutput, stdout, testData.args)
+	}
+}
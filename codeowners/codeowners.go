@@ -0,0 +1,373 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Package codeowners works out who owns a file, using the rules from
+// a GitHub or GitLab CODEOWNERS file.
+//
+// Each rule's pattern is compiled into a gitignore.Pattern, so that it
+// uses the same glob engine as the rest of this module.
+package codeowners
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ganbarodigital/go_glob/gitignore"
+)
+
+// Flavor says whose rules to use when reading a CODEOWNERS file.
+type Flavor int
+
+const (
+	// GitHub applies the rules that GitHub uses. There are no
+	// sections, and the last matching rule wins.
+	GitHub Flavor = iota
+
+	// GitLab applies the rules that GitLab uses. The file can be split
+	// into [Section]s, and the last matching rule in each section wins.
+	GitLab
+)
+
+// File holds the contents of a CODEOWNERS file.
+//
+// Call `Parse()` to create your File.
+type File struct {
+	// Flavor says whose rules the file was read with
+	Flavor Flavor
+
+	// Rules holds each rule from the file, in the order that they
+	// appear in the file.
+	Rules []*Rule
+
+	// Sections holds each GitLab section header from the file, in the
+	// order that they appear in the file. It is always empty for
+	// GitHub files.
+	Sections []*Section
+}
+
+// Section is a single GitLab section header, such as
+// `^[Docs][2] @docs-team`.
+type Section struct {
+	// Name is the text between the first pair of square brackets
+	Name string
+
+	// Line is where the section header appears in the file
+	Line int
+
+	// Optional is `true` if the header starts with a '^'
+	Optional bool
+
+	// Approvals is the number in the second pair of square brackets,
+	// or zero if there isn't one
+	Approvals int
+
+	// DefaultOwners are the owners listed after the header. They own
+	// any rules in the section that don't list owners of their own.
+	DefaultOwners []string
+}
+
+// Rule is a single pattern from a CODEOWNERS file, and its owners.
+type Rule struct {
+	// Pattern is the pattern, exactly as it appears in the file
+	Pattern string
+
+	// Owners are the users, groups and email addresses who own any
+	// path that matches the pattern. It is empty if the rule removes
+	// ownership.
+	Owners []string
+
+	// Line is where the rule appears in the file
+	Line int
+
+	// Section is the GitLab section that the rule belongs to. It is
+	// nil for rules that appear before the first section, and for
+	// all GitHub rules.
+	Section *Section
+
+	pattern         *gitignore.Pattern
+	matchesContents bool
+}
+
+// Parse turns the contents of a CODEOWNERS file into a File.
+//
+// Parse quietly skips any lines that it doesn't understand.
+func Parse(content []byte, flavor Flavor) *File {
+	retval := File{
+		Flavor: flavor,
+	}
+
+	var section *Section
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		if flavor == GitLab && (line[0] == '[' || strings.HasPrefix(line, "^[")) {
+			section = parseSection(line, i+1)
+			if section != nil {
+				retval.Sections = append(retval.Sections, section)
+			}
+			continue
+		}
+
+		fields := splitFields(line)
+		rule := newRule(fields[0], flavor)
+		rule.Line = i + 1
+		rule.Owners = fields[1:]
+		rule.Section = section
+		if len(rule.Owners) == 0 && section != nil {
+			rule.Owners = section.DefaultOwners
+		}
+
+		retval.Rules = append(retval.Rules, rule)
+	}
+
+	return &retval
+}
+
+// parseSection turns a GitLab section header into a Section. It
+// returns nil if the header is malformed.
+func parseSection(line string, lineNo int) *Section {
+	retval := Section{
+		Line: lineNo,
+	}
+
+	if line[0] == '^' {
+		retval.Optional = true
+		line = line[1:]
+	}
+
+	end := strings.IndexRune(line, ']')
+	if end < 0 {
+		return nil
+	}
+	retval.Name = strings.TrimSpace(line[1:end])
+	line = line[end+1:]
+
+	if strings.HasPrefix(line, "[") {
+		end = strings.IndexRune(line, ']')
+		if end < 0 {
+			return nil
+		}
+		retval.Approvals, _ = strconv.Atoi(line[1:end])
+		line = line[end+1:]
+	}
+
+	retval.DefaultOwners = splitFields(line)
+
+	// all done
+	return &retval
+}
+
+// splitFields splits a line at every unescaped space or tab, and
+// stops at the start of any trailing comment
+func splitFields(line string) []string {
+	var retval []string
+
+	field := strings.Builder{}
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			field.WriteByte(line[i])
+			if i+1 < len(line) {
+				i++
+				field.WriteByte(line[i])
+			}
+		case ' ', '\t':
+			if field.Len() > 0 {
+				retval = append(retval, field.String())
+				field.Reset()
+			}
+		case '#':
+			// the first field is a pattern, and a '#' inside it is a
+			// normal character
+			if field.Len() == 0 && len(retval) > 0 {
+				return retval
+			}
+			field.WriteByte(line[i])
+		default:
+			field.WriteByte(line[i])
+		}
+	}
+
+	if field.Len() > 0 {
+		retval = append(retval, field.String())
+	}
+
+	return retval
+}
+
+// newRule compiles a CODEOWNERS pattern into a Rule
+func newRule(text string, flavor Flavor) *Rule {
+	retval := Rule{
+		Pattern: text,
+	}
+
+	// CODEOWNERS has no '!' patterns
+	pattern := text
+	if strings.HasPrefix(pattern, "!") {
+		pattern = "\\" + pattern
+	}
+
+	switch flavor {
+	case GitLab:
+		// GitLab anchors everything, and treats patterns without a
+		// leading '/' as if they started with '**/'
+		if pattern == "*" {
+			pattern = "/**/*"
+		}
+		if !strings.HasPrefix(pattern, "/") {
+			pattern = "/**/" + pattern
+		}
+		if strings.HasSuffix(pattern, "/") {
+			pattern += "**/*"
+		}
+	default:
+		// GitHub treats a pattern as owning everything inside a
+		// folder that it matches ... unless the pattern ends in a
+		// wildcard, such as `docs/*`
+		retval.matchesContents = !hasWildcard(lastSegment(pattern))
+	}
+
+	retval.pattern = gitignore.ParsePattern("", pattern)
+
+	// all done
+	return &retval
+}
+
+// lastSegment returns everything after the last '/' in the pattern,
+// ignoring any trailing '/'
+func lastSegment(pattern string) string {
+	pattern = strings.TrimSuffix(pattern, "/")
+	return pattern[strings.LastIndex(pattern, "/")+1:]
+}
+
+// hasWildcard returns `true` if the pattern contains an unescaped '*',
+// '?' or '['
+func hasWildcard(pattern string) bool {
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '*', '?', '[':
+			return true
+		}
+	}
+
+	return false
+}
+
+// Match returns `true` if the rule's pattern matches the given path.
+//
+// path must be a slash-separated path to a file, relative to the top
+// of the repository.
+func (r *Rule) Match(path string) bool {
+	if r.pattern.Match(path, false) {
+		return true
+	}
+
+	if !r.matchesContents {
+		return false
+	}
+
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' && r.pattern.Match(path[:i], true) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Resolve returns the rules that decide who owns the given path.
+//
+// For GitHub files, there is at most one rule: the last one that
+// matches. For GitLab files, there is at most one rule per section:
+// the last one in that section that matches. Sections with the same
+// name, ignoring case, count as the same section.
+//
+// path must be a slash-separated path to a file, relative to the top
+// of the repository.
+func (f *File) Resolve(path string) []*Rule {
+	path = strings.TrimPrefix(path, "/")
+
+	// the last rule that matched, for each section
+	var order []string
+	matches := map[string]*Rule{}
+
+	for _, rule := range f.Rules {
+		key := ""
+		if f.Flavor == GitLab && rule.Section != nil {
+			key = "[" + strings.ToLower(rule.Section.Name)
+		}
+
+		if !rule.Match(path) {
+			continue
+		}
+
+		if _, ok := matches[key]; !ok {
+			order = append(order, key)
+		}
+		matches[key] = rule
+	}
+
+	retval := make([]*Rule, 0, len(order))
+	for _, key := range order {
+		retval = append(retval, matches[key])
+	}
+
+	return retval
+}
+
+// Owners returns everyone who owns the given path, with no duplicates.
+// It returns an empty list if nobody owns the path.
+//
+// path must be a slash-separated path to a file, relative to the top
+// of the repository.
+func (f *File) Owners(path string) []string {
+	var retval []string
+
+	seen := map[string]bool{}
+	for _, rule := range f.Resolve(path) {
+		for _, owner := range rule.Owners {
+			if !seen[owner] {
+				seen[owner] = true
+				retval = append(retval, owner)
+			}
+		}
+	}
+
+	return retval
+}
@@ -0,0 +1,288 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// githubExample is based on the example in GitHub's documentation
+const githubExample = `# This is a comment.
+*       @global-owner1 @global-owner2
+*.js    @js-owner # This is an inline comment.
+*.go docs@example.com
+/build/logs/ @doctocat
+docs/*  docs@example.com
+apps/ @octocat
+/docs/ @doctocat
+/scripts/ @doctocat @octocat
+**/logs @octocat
+/apps/ @octocat
+/apps/github
+\#hash @hash-owner
+my\ file.txt @space-owner
+`
+
+func TestParseReadsGitHubRules(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Parse([]byte(githubExample), GitHub)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Len(t, actualResult.Rules, 13)
+	assert.Empty(t, actualResult.Sections)
+
+	assert.Equal(t, "*", actualResult.Rules[0].Pattern)
+	assert.Equal(t, []string{"@global-owner1", "@global-owner2"}, actualResult.Rules[0].Owners)
+	assert.Equal(t, 2, actualResult.Rules[0].Line)
+
+	assert.Equal(t, "*.js", actualResult.Rules[1].Pattern)
+	assert.Equal(t, []string{"@js-owner"}, actualResult.Rules[1].Owners)
+
+	assert.Equal(t, "/apps/github", actualResult.Rules[10].Pattern)
+	assert.Empty(t, actualResult.Rules[10].Owners)
+
+	assert.Equal(t, "my\\ file.txt", actualResult.Rules[12].Pattern)
+}
+
+func TestResolveUsesLastMatchingGitHubRule(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	f := Parse([]byte(githubExample), GitHub)
+
+	testDataSet := []struct {
+		path           string
+		expectedLine   int
+		expectedOwners []string
+	}{
+		{"README.md", 2, []string{"@global-owner1", "@global-owner2"}},
+		{"src/app.js", 3, []string{"@js-owner"}},
+		{"main.go", 4, []string{"docs@example.com"}},
+		{"build/logs/today.txt", 10, []string{"@octocat"}},
+		{"docs/getting-started.md", 8, []string{"@doctocat"}},
+		{"src/docs/getting-started.md", 2, []string{"@global-owner1", "@global-owner2"}},
+		{"src/docs/build-app/troubleshooting.md", 2, []string{"@global-owner1", "@global-owner2"}},
+		{"src/apps/main.c", 7, []string{"@octocat"}},
+		{"deeply/nested/logs/x.txt", 10, []string{"@octocat"}},
+		{"apps/main.c", 11, []string{"@octocat"}},
+		{"apps/github/main.c", 12, nil},
+		{"#hash", 13, []string{"@hash-owner"}},
+		{"my file.txt", 14, []string{"@space-owner"}},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualRules := f.Resolve(testData.path)
+		actualOwners := f.Owners(testData.path)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Len(t, actualRules, 1, testData.path)
+		assert.Equal(t, testData.expectedLine, actualRules[0].Line, testData.path)
+		assert.Equal(t, testData.expectedOwners, actualOwners, testData.path)
+	}
+}
+
+func TestResolveReturnsNothingWhenNoRuleMatches(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	f := Parse([]byte("/docs/ @doctocat\n"), GitHub)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualRules := f.Resolve("src/main.go")
+	actualOwners := f.Owners("src/main.go")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Empty(t, actualRules)
+	assert.Empty(t, actualOwners)
+}
+
+// gitlabExample is based on the examples in GitLab's documentation
+const gitlabExample = `* @default-owner
+
+[Documentation] @docs-team
+docs/
+README.md @readme-owner
+
+^[Database][2] @database-team
+model/db/
+config/db/database-setup.md @docs-team
+
+[DOCUMENTATION]
+/guides/ @guides-owner
+`
+
+func TestParseReadsGitLabSections(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Parse([]byte(gitlabExample), GitLab)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Len(t, actualResult.Sections, 3)
+	assert.Equal(
+		t,
+		&Section{Name: "Documentation", Line: 3, DefaultOwners: []string{"@docs-team"}},
+		actualResult.Sections[0],
+	)
+	assert.Equal(
+		t,
+		&Section{Name: "Database", Line: 7, Optional: true, Approvals: 2, DefaultOwners: []string{"@database-team"}},
+		actualResult.Sections[1],
+	)
+
+	assert.Len(t, actualResult.Rules, 6)
+	assert.Nil(t, actualResult.Rules[0].Section)
+	assert.Equal(t, []string{"@docs-team"}, actualResult.Rules[1].Owners)
+	assert.Equal(t, []string{"@readme-owner"}, actualResult.Rules[2].Owners)
+	assert.Same(t, actualResult.Sections[1], actualResult.Rules[3].Section)
+	assert.Equal(t, []string{"@database-team"}, actualResult.Rules[3].Owners)
+}
+
+func TestResolveUsesLastMatchingGitLabRuleInEachSection(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	f := Parse([]byte(gitlabExample), GitLab)
+
+	testDataSet := []struct {
+		path           string
+		expectedLines  []int
+		expectedOwners []string
+	}{
+		{"main.go", []int{1}, []string{"@default-owner"}},
+		{"docs/index.md", []int{1, 4}, []string{"@default-owner", "@docs-team"}},
+		{"src/docs/index.md", []int{1, 4}, []string{"@default-owner", "@docs-team"}},
+		{"src/README.md", []int{1, 5}, []string{"@default-owner", "@readme-owner"}},
+		{"model/db/schema.rb", []int{1, 8}, []string{"@default-owner", "@database-team"}},
+		{"config/db/database-setup.md", []int{1, 9}, []string{"@default-owner", "@docs-team"}},
+		{"guides/README.md", []int{1, 12}, []string{"@default-owner", "@guides-owner"}},
+		{"src/guides/x.md", []int{1}, []string{"@default-owner"}},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualRules := f.Resolve(testData.path)
+		actualOwners := f.Owners(testData.path)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		var actualLines []int
+		for _, rule := range actualRules {
+			actualLines = append(actualLines, rule.Line)
+		}
+		assert.Equal(t, testData.expectedLines, actualLines, testData.path)
+		assert.Equal(t, testData.expectedOwners, actualOwners, testData.path)
+	}
+}
+
+func TestGitLabPatternsWithoutTrailingSlashOnlyMatchFiles(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	f := Parse([]byte("/docs @docs-team\n"), GitLab)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	fileOwners := f.Owners("docs")
+	contentOwners := f.Owners("docs/index.md")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, []string{"@docs-team"}, fileOwners)
+	assert.Empty(t, contentOwners)
+}
+
+func TestGitHubWildcardPatternsOnlyMatchDirectChildren(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	f := Parse([]byte("docs/* @docs-team\n/build/ @build-team\n"), GitHub)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	directOwners := f.Owners("docs/getting-started.md")
+	nestedOwners := f.Owners("docs/build-app/troubleshooting.md")
+	contentOwners := f.Owners("build/logs/today.txt")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, []string{"@docs-team"}, directOwners)
+	assert.Empty(t, nestedOwners)
+	assert.Equal(t, []string{"@build-team"}, contentOwners)
+}
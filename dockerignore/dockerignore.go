@@ -0,0 +1,559 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Package dockerignore decides which paths Docker would leave out of a
+// build context, using the rules from a .dockerignore file.
+//
+// Docker's rules are not the same as git's. Patterns are always
+// relative to the top of the build context, `**` can appear anywhere,
+// exceptions start with `!`, and both patterns and paths are cleaned
+// before they are compared.
+package dockerignore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	glob "github.com/ganbarodigital/go_glob"
+)
+
+const (
+	matchTypeExact = iota
+	matchTypePrefix
+	matchTypeSuffix
+	matchTypeGlob
+)
+
+// Pattern is a single rule from a .dockerignore file.
+//
+// Call `ParsePattern()` or `Parse()` to create your Pattern structs.
+type Pattern struct {
+	// Line is where this pattern appears in its .dockerignore file. It
+	// is zero for patterns created by `ParsePattern()`.
+	Line int
+
+	// Text is the cleaned-up pattern, without any leading '!'
+	Text string
+
+	exception bool
+	matchType int
+	items     []item
+}
+
+// item is either a run of path segments, or a '**' that sits between
+// two runs
+type item struct {
+	globstar bool
+	segments []*glob.Glob
+}
+
+// ParsePattern turns a single line from a .dockerignore file into a
+// Pattern.
+//
+// Returns nil if the line is blank or a comment. Returns an error if
+// Docker would reject the pattern.
+func ParsePattern(line string) (*Pattern, error) {
+	// Docker only treats '#' as a comment at the very start of a line
+	if strings.HasPrefix(line, "#") {
+		return nil, nil
+	}
+
+	text := strings.TrimSpace(line)
+	if text == "" {
+		return nil, nil
+	}
+
+	retval := Pattern{}
+	if text[0] == '!' {
+		retval.exception = true
+		text = strings.TrimSpace(text[1:])
+		if text == "" {
+			return nil, errors.New("illegal exclusion pattern: \"!\"")
+		}
+	}
+
+	// patterns are always relative to the top of the build context
+	text = path.Clean(text)
+	if len(text) > 1 && text[0] == '/' {
+		text = text[1:]
+	}
+	retval.Text = text
+
+	// Docker uses the standard library to reject bad patterns
+	_, err := path.Match(text, ".")
+	if err != nil {
+		return nil, fmt.Errorf("bad .dockerignore pattern '%s': %s", line, err.Error())
+	}
+
+	err = retval.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	// all done
+	return &retval, nil
+}
+
+// Parse turns the contents of a .dockerignore file into a list of
+// Patterns, in the order that they appear in the file.
+//
+// Returns an error if Docker would reject any of the patterns.
+func Parse(content []byte) ([]*Pattern, error) {
+	var retval []*Pattern
+
+	// Docker ignores any UTF-8 byte order mark
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	for i, line := range strings.Split(string(content), "\n") {
+		p, err := ParsePattern(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", i+1, err.Error())
+		}
+		if p == nil {
+			continue
+		}
+
+		p.Line = i + 1
+		retval = append(retval, p)
+	}
+
+	return retval, nil
+}
+
+// compile works out how Docker would match the pattern, and builds
+// the Globs we need to do the same
+//
+// Docker only uses a regex when the pattern has wildcards in it. Simple
+// patterns are compared as plain strings, and we have to do the same
+// to get the same results.
+func (p *Pattern) compile() error {
+	runes := []rune(p.Text)
+
+	var segments []string
+	segBuf := strings.Builder{}
+	inRun := false
+	inBracket := false
+
+	p.matchType = matchTypeExact
+
+	addSegment := func() {
+		segments = append(segments, segBuf.String())
+		segBuf.Reset()
+	}
+	addRun := func() {
+		if inRun {
+			addSegment()
+			p.items = append(p.items, item{segments: newGlobs(segments)})
+		}
+		segments = nil
+		inRun = false
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		// special case - we're inside a bracket expression
+		if inBracket {
+			segBuf.WriteRune(r)
+			if r == '\\' && i+1 < len(runes) {
+				i++
+				segBuf.WriteRune(runes[i])
+			} else if r == ']' {
+				inBracket = false
+			}
+			continue
+		}
+
+		switch {
+		case r == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			start := i
+			i++
+
+			// Docker treats '**/' the same as '**'
+			if i+1 < len(runes) && runes[i+1] == '/' {
+				i++
+			}
+
+			if i+1 < len(runes) || p.matchType != matchTypeExact {
+				p.matchType = matchTypeGlob
+			} else {
+				p.matchType = matchTypePrefix
+			}
+			if start == 0 {
+				p.matchType = matchTypeSuffix
+			}
+
+			addRun()
+			p.items = append(p.items, item{globstar: true})
+			continue
+		case r == '*' || r == '?':
+			p.matchType = matchTypeGlob
+			segBuf.WriteRune(r)
+		case r == '\\':
+			if i+1 < len(runes) {
+				p.matchType = matchTypeGlob
+				i++
+				if runes[i] == '/' {
+					addSegment()
+				} else {
+					segBuf.WriteRune(r)
+					segBuf.WriteRune(runes[i])
+				}
+			} else {
+				segBuf.WriteRune(r)
+			}
+		case r == '[':
+			p.matchType = matchTypeGlob
+			inBracket = true
+			segBuf.WriteRune(r)
+
+			// Docker doesn't support '[!...]', so the '!' is a member
+			// of the set
+			if i+1 < len(runes) && runes[i+1] == '!' {
+				i++
+				segBuf.WriteString("\\!")
+			}
+		case r == ']':
+			p.matchType = matchTypeGlob
+			segBuf.WriteRune(r)
+		case r == '/':
+			addSegment()
+		default:
+			segBuf.WriteRune(r)
+		}
+
+		inRun = true
+	}
+	addRun()
+
+	// make sure that every Glob compiles
+	for _, it := range p.items {
+		for _, g := range it.segments {
+			_, err := g.Match("")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// all done
+	return nil
+}
+
+// newGlobs turns a list of path segments into a list of Globs
+func newGlobs(segments []string) []*glob.Glob {
+	retval := make([]*glob.Glob, len(segments))
+	for i, segment := range segments {
+		retval[i] = glob.NewGlob(segment)
+	}
+
+	return retval
+}
+
+// IsException returns `true` if the pattern started with a '!', and
+// re-includes any paths that it matches.
+func (p *Pattern) IsException() bool {
+	return p.exception
+}
+
+// Match returns `true` if the given path matches the pattern. It
+// ignores any leading '!' in the pattern.
+//
+// path must be a clean, slash-separated path, relative to the top of
+// the build context.
+//
+// Match does not look at the path's parent folders. Use a Matcher if
+// you need to know whether Docker would exclude the path.
+func (p *Pattern) Match(path string) bool {
+	switch p.matchType {
+	case matchTypeExact:
+		return path == p.Text
+	case matchTypePrefix:
+		return strings.HasPrefix(path, p.Text[:len(p.Text)-2])
+	case matchTypeSuffix:
+		suffix := p.Text[2:]
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+		return len(suffix) > 0 && suffix[0] == '/' && path == suffix[1:]
+	}
+
+	return matchItems(p.items, path)
+}
+
+// matchItems returns `true` if the whole of input satisfies the list
+// of items
+func matchItems(items []item, input string) bool {
+	if len(items) == 0 {
+		return input == ""
+	}
+
+	if items[0].globstar {
+		// a '**' at the end matches everything that is left
+		if len(items) == 1 {
+			return true
+		}
+
+		// any other '**' matches nothing, or anything that ends in
+		// a '/'
+		if matchItems(items[1:], input) {
+			return true
+		}
+		for i := 0; i < len(input); i++ {
+			if input[i] == '/' && matchItems(items[1:], input[i+1:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// the last run has to match everything that is left
+	if len(items) == 1 {
+		return matchRun(items[0].segments, input)
+	}
+
+	for i := 0; i <= len(input); i++ {
+		if matchRun(items[0].segments, input[:i]) && matchItems(items[1:], input[i:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRun returns `true` if input has one path segment for each
+// segment in the run, and each one matches
+func matchRun(segments []*glob.Glob, input string) bool {
+	names := strings.Split(input, "/")
+	if len(names) != len(segments) {
+		return false
+	}
+
+	for i, name := range names {
+		// we have already thrown away any patterns that don't compile
+		success, _ := segments[i].Match(name)
+		if !success {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Matcher holds the patterns from a .dockerignore file, and applies
+// them the same way that Docker does.
+//
+// Call `NewMatcher()` to create your Matcher.
+type Matcher struct {
+	patterns []*Pattern
+}
+
+// NewMatcher creates a Matcher that holds the given patterns
+func NewMatcher(patterns ...*Pattern) *Matcher {
+	retval := Matcher{
+		patterns: patterns,
+	}
+
+	return &retval
+}
+
+// Patterns returns the list of patterns held by the Matcher, in the
+// order that they are applied.
+func (m *Matcher) Patterns() []*Pattern {
+	return m.patterns
+}
+
+// Excluded returns `true` if Docker would leave the given path out of
+// the build context.
+//
+// path must be a slash-separated path, relative to the top of the build
+// context. It is cleaned before it is matched.
+//
+// A pattern that matches any of the path's parent folders also matches
+// the path. Unlike git, a later exception can re-include a path inside
+// an excluded folder.
+func (m *Matcher) Excluded(name string) bool {
+	name = path.Clean(name)
+
+	parentPath := path.Dir(name)
+	var parents []string
+	if parentPath != "." {
+		parents = strings.Split(parentPath, "/")
+	}
+
+	excluded := false
+	for _, p := range m.patterns {
+		// an exclusion can't change anything if we're already
+		// excluded, and the same goes for an exception if we're
+		// not
+		if p.exception != excluded {
+			continue
+		}
+
+		success := p.Match(name)
+		for i := 0; !success && i < len(parents); i++ {
+			success = p.Match(strings.Join(parents[:i+1], "/"))
+		}
+
+		if success {
+			excluded = !p.exception
+		}
+	}
+
+	return excluded
+}
+
+// defaultDockerfile is the Dockerfile that `docker build` uses when
+// you don't pass it `--file`
+const defaultDockerfile = "Dockerfile"
+
+// ListFS returns every file and folder in fsys that Docker would put
+// into the build context, in the order that Docker would add them.
+//
+// dockerfile is the path to the Dockerfile, relative to the top of
+// fsys. Pass an empty string to use `Dockerfile`. The docker CLI always
+// sends the .dockerignore file and the Dockerfile, even if the rules
+// exclude them, and so does ListFS.
+//
+// It walks the tree the same way that Docker's classic builder does.
+// Docker doesn't look inside an excluded folder unless an exception
+// starts with that folder's path, so an exception like `!**/keep` can
+// not re-include anything from inside one.
+func (m *Matcher) ListFS(fsys fs.FS, dockerfile string) ([]string, error) {
+	m, err := m.withForcedIncludes(dockerfile)
+	if err != nil {
+		return nil, err
+	}
+
+	var retval []string
+
+	err = fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+
+		if !m.Excluded(name) {
+			retval = append(retval, name)
+			return nil
+		}
+
+		if d.IsDir() && !m.mightReinclude(name) {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
+// withForcedIncludes returns a Matcher that can't exclude the
+// .dockerignore file or the Dockerfile
+//
+// the docker CLI does this by adding an exception for each of them
+// to the end of the rules, if the rules exclude them
+func (m *Matcher) withForcedIncludes(dockerfile string) (*Matcher, error) {
+	if dockerfile == "" {
+		dockerfile = defaultDockerfile
+	}
+
+	patterns := append([]*Pattern(nil), m.patterns...)
+	for _, name := range []string{".dockerignore", dockerfile} {
+		if !m.Excluded(name) {
+			continue
+		}
+
+		p, err := ParsePattern("!" + name)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+
+	return NewMatcher(patterns...), nil
+}
+
+// mightReinclude returns `true` if an exception could re-include
+// something inside the given excluded folder
+func (m *Matcher) mightReinclude(dir string) bool {
+	dirSlash := dir + "/"
+	for _, p := range m.patterns {
+		if p.exception && strings.HasPrefix(p.Text+"/", dirSlash) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoadFS reads the .dockerignore file at the top of fsys, and returns
+// a Matcher that holds its patterns. It returns an empty Matcher if
+// there is no .dockerignore file.
+func LoadFS(fsys fs.FS) (*Matcher, error) {
+	content, err := fs.ReadFile(fsys, ".dockerignore")
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return NewMatcher(), nil
+		}
+		return nil, err
+	}
+
+	patterns, err := Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMatcher(patterns...), nil
+}
+
+// ListFS returns every file and folder in fsys that Docker would put
+// into the build context, using the .dockerignore file at the top of
+// fsys. Pass an empty dockerfile to use `Dockerfile`. See
+// `Matcher.ListFS()` for details.
+func ListFS(fsys fs.FS, dockerfile string) ([]string, error) {
+	m, err := LoadFS(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.ListFS(fsys, dockerfile)
+}
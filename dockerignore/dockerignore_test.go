@@ -0,0 +1,450 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package dockerignore
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type matchTestDataStruct struct {
+	pattern         string
+	path            string
+	expectedSuccess bool
+}
+
+func TestParsePatternSkipsBlankLinesAndComments(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []string{
+		"",
+		"   ",
+		"# a comment",
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, err := ParsePattern(testData)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err)
+		assert.Nil(t, actualResult, testData)
+	}
+}
+
+func TestParsePatternCleansPatterns(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := map[string]string{
+		"  foo  ":        "foo",
+		"/foo":           "foo",
+		"./foo/":         "foo",
+		"foo/../bar":     "bar",
+		"! /foo":         "foo",
+		" # not comment": "# not comment",
+		"**/":            "**",
+	}
+
+	for input, expectedResult := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, err := ParsePattern(input)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, input)
+		assert.Equal(t, expectedResult, actualResult.Text, input)
+	}
+}
+
+func TestParsePatternDetectsExceptions(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	plain, err1 := ParsePattern("foo")
+	exception, err2 := ParsePattern("!foo")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.False(t, plain.IsException())
+	assert.True(t, exception.IsException())
+}
+
+func TestParsePatternReturnsErrorForBadPatterns(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []string{
+		"!",
+		"abc[",
+		"[z-",
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, err := ParsePattern(testData)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Error(t, err, testData)
+		assert.Nil(t, actualResult, testData)
+	}
+}
+
+func TestPatternMatch(t *testing.T) {
+	t.Parallel()
+
+	// most of these come from Docker's own test suite
+	testDataSet := []matchTestDataStruct{
+		{"**", "file", true},
+		{"**/", "file", true},
+		{"**", "dir/file", true},
+		{"**/**", "dir/file", true},
+		{"dir/**", "dir/file", true},
+		{"dir/**", "dir/dir2/file", true},
+		{"**/dir", "dir", true},
+		{"**/dir2/*", "dir/dir2/file", true},
+		{"**/dir2/**", "dir/dir2/dir3/file", true},
+		{"**file", "file", true},
+		{"**file", "dir/file", true},
+		{"**/file", "dir/file", true},
+		{"**file", "dir/dir/file", true},
+		{"**/file", "dir/dir/file", true},
+		{"**/file*", "dir/dir/file", true},
+		{"**/file*", "dir/dir/file.txt", true},
+		{"**/file*txt", "dir/dir/file.txt", true},
+		{"**/file*.txt", "dir/dir/file.txt", true},
+		{"**/file*.txt*", "dir/dir/file.txt", true},
+		{"**/**/*.txt", "dir/dir/file.txt", true},
+		{"**/**/*.txt2", "dir/dir/file.txt", false},
+		{"**/*.txt", "file.txt", true},
+		{"**/**/*.txt", "file.txt", true},
+		{"a**/*.txt", "a/file.txt", true},
+		{"a**/*.txt", "a/dir/file.txt", true},
+		{"a**/*.txt", "a/dir/dir/file.txt", true},
+		{"a/*.txt", "a/dir/file.txt", false},
+		{"a/*.txt", "a/file.txt", true},
+		{"a/*.txt**", "a/file.txt", true},
+		{"a[b-d]e", "ae", false},
+		{"a[b-d]e", "ace", true},
+		{"a[b-d]e", "aae", false},
+		{"a[^b-d]e", "aze", true},
+		{".*", ".foo", true},
+		{".*", "foo", false},
+		{"abc.def", "abcdef", false},
+		{"abc.def", "abc.def", true},
+		{"abc.def", "abcZdef", false},
+		{"abc?def", "abcZdef", true},
+		{"abc?def", "abcdef", false},
+		{"a\\\\", "a\\", true},
+		{"**/foo/bar", "foo/bar", true},
+		{"**/foo/bar", "dir/foo/bar", true},
+		{"**/foo/bar", "dir/dir2/foo/bar", true},
+		{"abc/**", "abc", false},
+		{"abc/**", "abc/def", true},
+		{"abc/**", "abc/def/ghi", true},
+		{"**/.foo", ".foo", true},
+		{"**/.foo", "bar.foo", false},
+		{"a(b)c/def", "a(b)c/def", true},
+		{"a(b)c/def", "a(b)c/xyz", false},
+		{"a.|)$(}+{bc", "a.|)$(}+{bc", true},
+		{"dist/*.whl", "dist/proxy.py-2.4.0rc3.dev36+g08acad9-py3-none-any.whl", true},
+		// '*' and '?' never match a '/'
+		{"a*", "a/b", false},
+		{"a?b", "a/b", false},
+		// Docker doesn't support '[!...]'
+		{"[!a]", "!", true},
+		{"[!a]", "b", false},
+		// '**' in the middle of a name matches zero or more folders
+		{"a**b", "ab", true},
+		{"a**b", "a/x/b", true},
+		{"a**b", "axb", false},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		p, err := ParsePattern(testData.pattern)
+		assert.Nil(t, err, testData)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualSuccess := p.Match(testData.path)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedSuccess, actualSuccess, testData)
+	}
+}
+
+func TestParseRecordsLineNumbers(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	content := []byte("\xef\xbb\xbf# comment\n*.o\n\n!keep.o\n")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := Parse(content)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Len(t, actualResult, 2)
+	assert.Equal(t, "*.o", actualResult[0].Text)
+	assert.Equal(t, 2, actualResult[0].Line)
+	assert.Equal(t, "keep.o", actualResult[1].Text)
+	assert.Equal(t, 4, actualResult[1].Line)
+}
+
+func TestParseReturnsErrorWithLineNumber(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	content := []byte("*.o\nabc[\n")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := Parse(content)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+	assert.Nil(t, actualResult)
+}
+
+func TestMatcherExcluded(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	patterns, err := Parse([]byte("*.md\n!README*.md\nREADME-secret.md\nvendor\n!vendor/keep\n"))
+	assert.Nil(t, err)
+	m := NewMatcher(patterns...)
+
+	testDataSet := map[string]bool{
+		"CHANGELOG.md":      true,
+		"README.md":         false,
+		"README-secret.md":  true,
+		"docs/notes.md":     false,
+		"vendor":            true,
+		"vendor/lib/x.go":   true,
+		"vendor/keep":       false,
+		"vendor/keep/x.go":  false,
+		"./vendor/../a.md":  true,
+		"src/vendor/x.go":   false,
+		"src/main.go":       false,
+		"vendor/keep/../x":  true,
+		"vendor/keepsake/x": true,
+	}
+
+	for input, expectedResult := range testDataSet {
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := m.Excluded(input)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, expectedResult, actualResult, input)
+	}
+}
+
+func TestListFS(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fsys := fstest.MapFS{
+		".dockerignore":         {Data: []byte("node_modules\n*/temp*\n.git\nbuild\n!build/keep\n!**/wanted\n")},
+		"Dockerfile":            {},
+		"main.go":               {},
+		".git/config":           {},
+		"node_modules/x/y.js":   {},
+		"node_modules/wanted":   {},
+		"src/temp.txt":          {},
+		"src/main.go":           {},
+		"src/deep/temp.txt":     {},
+		"build/out.o":           {},
+		"build/keep/answer.txt": {},
+	}
+	expectedResult := []string{
+		".dockerignore",
+		"Dockerfile",
+		"build/keep",
+		"build/keep/answer.txt",
+		"main.go",
+		"src",
+		"src/deep",
+		"src/deep/temp.txt",
+		"src/main.go",
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := ListFS(fsys, "")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestListFSWorksWithoutDockerignoreFile(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fsys := fstest.MapFS{
+		"Dockerfile":  {},
+		"src/main.go": {},
+	}
+	expectedResult := []string{
+		"Dockerfile",
+		"src",
+		"src/main.go",
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := ListFS(fsys, "")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestListFSAlwaysIncludesDockerignoreAndDockerfile(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		dockerignore   string
+		dockerfile     string
+		expectedResult []string
+	}{
+		{
+			dockerignore:   "*\n",
+			expectedResult: []string{".dockerignore", "Dockerfile"},
+		},
+		{
+			dockerignore: "*\n",
+			dockerfile:   "build/app.Dockerfile",
+			// Docker looks inside the excluded folder, but leaves the
+			// folder itself out
+			expectedResult: []string{".dockerignore", "build/app.Dockerfile"},
+		},
+		{
+			dockerignore:   ".dockerignore\nDockerfile\nbuild\n",
+			expectedResult: []string{".dockerignore", "Dockerfile", "main.go"},
+		},
+		{
+			dockerignore:   "main.go\n",
+			dockerfile:     "build/app.Dockerfile",
+			expectedResult: []string{".dockerignore", "Dockerfile", "build", "build/app.Dockerfile", "build/out.o"},
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		fsys := fstest.MapFS{
+			".dockerignore":        {Data: []byte(testData.dockerignore)},
+			"Dockerfile":           {},
+			"main.go":              {},
+			"build/app.Dockerfile": {},
+			"build/out.o":          {},
+		}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, err := ListFS(fsys, testData.dockerfile)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, testData)
+		assert.Equal(t, testData.expectedResult, actualResult, testData)
+	}
+}
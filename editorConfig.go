@@ -0,0 +1,409 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EditorConfigSyntax is an option for NewGlob(). It makes the Glob use
+// the syntax of EditorConfig section headers, instead of UNIX shell
+// syntax.
+//
+// In this syntax:
+//
+//	?            Matches exactly one character, except '/'
+//	*            Matches zero or more characters, except '/'
+//	**           Matches zero or more characters, including '/'
+//	[...]        Matches any one character within the brackets
+//	{s1,s2}      Matches any one of the comma-separated strings
+//	{num1..num2} Matches any whole number between num1 and num2
+//
+// A '/**/' also matches a single '/'.
+func EditorConfigSyntax(g *Glob) {
+	g.parser = parseEditorConfigPattern
+	g.syntax = editorConfigSyntax
+}
+
+// numericRangeRegex recognises the contents of a {num1..num2} expression
+var numericRangeRegex = regexp.MustCompile(`^([+-]?[0-9]+)\.\.([+-]?[0-9]+)$`)
+
+func parseEditorConfigPattern(pattern string) []parsedPattern {
+	return parseEditorConfigRunes([]rune(pattern))
+}
+
+// parseEditorConfigRunes does the work for parseEditorConfigPattern().
+// It calls itself to parse each choice inside a {s1,s2} expression.
+func parseEditorConfigRunes(runes []rune) []parsedPattern {
+	// what we'll be sending back
+	var retval []parsedPattern
+
+	patternBuf := strings.Builder{}
+
+	addPart := func(part parsedPattern) {
+		if patternBuf.Len() > 0 {
+			retval = append(
+				retval,
+				parsedPattern{
+					pattern:     patternBuf.String(),
+					patternType: patternTypeStatic,
+				},
+			)
+			patternBuf.Reset()
+		}
+		retval = append(retval, part)
+	}
+
+	for i := 0; i < len(runes); i++ {
+		p := runes[i]
+
+		switch p {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				patternBuf.WriteString(regexp.QuoteMeta(string(runes[i])))
+			} else {
+				patternBuf.WriteString("\\\\")
+			}
+		case '?':
+			addPart(parsedPattern{
+				pattern:     "?",
+				patternType: patternTypeSegmentSingleMatch,
+			})
+		case '*':
+			if i+1 >= len(runes) || runes[i+1] != '*' {
+				addPart(parsedPattern{
+					pattern:     "*",
+					patternType: patternTypeSegmentMultiMatch,
+				})
+				continue
+			}
+			i++
+
+			// special case - '/**/' can also match a single '/'
+			bufContent := patternBuf.String()
+			if strings.HasSuffix(bufContent, "/") && i+1 < len(runes) && runes[i+1] == '/' {
+				i++
+				patternBuf.Reset()
+				patternBuf.WriteString(bufContent[:len(bufContent)-1])
+				addPart(parsedPattern{
+					pattern:     "/**/",
+					patternType: patternTypeGlobStar,
+				})
+				continue
+			}
+
+			addPart(parsedPattern{
+				pattern:     "**",
+				patternType: patternTypeGlobStar,
+			})
+		case '[':
+			// a bracket expression can never match a '/'
+			end := findBracketEnd(runes, i)
+			if end < 0 || containsRune(runes[i:end], '/') {
+				patternBuf.WriteString("\\[")
+				continue
+			}
+			patternBuf.WriteString(buildCharClass(runes[i+1 : end]))
+			i = end
+		case '{':
+			end := findBraceEnd(runes, i)
+			if end < 0 {
+				patternBuf.WriteString("\\{")
+				continue
+			}
+
+			contents := runes[i+1 : end]
+			if _, _, ok := parseNumericRange(string(runes[i : end+1])); ok {
+				addPart(parsedPattern{
+					pattern:     string(runes[i : end+1]),
+					patternType: patternTypeNumericRange,
+				})
+				i = end
+				continue
+			}
+
+			choices := splitAlternatives(contents)
+			if len(choices) < 2 {
+				// braces without a comma are normal characters, but
+				// we still need to parse what's inside them
+				patternBuf.WriteString("\\{")
+				continue
+			}
+
+			part := parsedPattern{
+				pattern:     string(runes[i : end+1]),
+				patternType: patternTypeAlternatives,
+			}
+			for _, choice := range choices {
+				part.alternatives = append(part.alternatives, parseEditorConfigRunes(choice))
+			}
+			addPart(part)
+			i = end
+		default:
+			patternBuf.WriteString(regexp.QuoteMeta(string(p)))
+		}
+	}
+
+	// deal with any static chars at the end of the pattern
+	if patternBuf.Len() > 0 {
+		retval = append(
+			retval,
+			parsedPattern{
+				pattern:     patternBuf.String(),
+				patternType: patternTypeStatic,
+			},
+		)
+	}
+
+	// all done
+	return retval
+}
+
+// containsRune returns `true` if r appears in runes
+func containsRune(runes []rune, r rune) bool {
+	for _, candidate := range runes {
+		if candidate == r {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findBraceEnd returns the position of the '}' that closes the brace
+// expression starting at runes[start], or -1 if it is never closed
+func findBraceEnd(runes []rune, start int) int {
+	depth := 0
+	for i := start; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// splitAlternatives splits the contents of a brace expression at each
+// comma that isn't inside a nested brace expression
+func splitAlternatives(runes []rune) [][]rune {
+	var retval [][]rune
+
+	depth := 0
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				retval = append(retval, runes[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(retval, runes[start:])
+}
+
+// parseNumericRange extracts the two numbers from a {num1..num2}
+// expression. The smaller number is always returned first.
+func parseNumericRange(pattern string) (int64, int64, bool) {
+	pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "{"), "}")
+	matches := numericRangeRegex.FindStringSubmatch(pattern)
+	if matches == nil {
+		return 0, 0, false
+	}
+
+	lo, err := strconv.ParseInt(matches[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	hi, err := strconv.ParseInt(matches[2], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+
+	return lo, hi, true
+}
+
+// buildNumericRangeRegex returns a regex that matches any whole number
+// between lo and hi. Like EditorConfig, it accepts numbers that have
+// leading zeros or a leading '+'.
+func buildNumericRangeRegex(lo, hi int64) string {
+	var choices []string
+
+	if hi >= 0 {
+		var positiveLo uint64
+		if lo > 0 {
+			positiveLo = uint64(lo)
+		}
+		choices = append(
+			choices,
+			`\+?0*(?:`+strings.Join(buildUintRangeRegex(positiveLo, uint64(hi)), "|")+")",
+		)
+	}
+
+	if lo < 0 {
+		// we work out the range of the digits after the '-'
+		var negativeLo uint64 = 1
+		if hi < 0 {
+			negativeLo = negate(hi)
+		}
+		choices = append(
+			choices,
+			`-0*(?:`+strings.Join(buildUintRangeRegex(negativeLo, negate(lo)), "|")+")",
+		)
+
+		if hi >= 0 {
+			choices = append(choices, "-0+")
+		}
+	}
+
+	return "(?:" + strings.Join(choices, "|") + ")"
+}
+
+// negate returns the size of a negative number, without overflowing
+func negate(n int64) uint64 {
+	return uint64(-(n + 1)) + 1
+}
+
+// buildUintRangeRegex returns a list of regexes that, between them,
+// match any number between lo and hi
+func buildUintRangeRegex(lo, hi uint64) []string {
+	var retval []string
+
+	for {
+		loDigits := strconv.FormatUint(lo, 10)
+
+		// we deal with one length of number at a time
+		end := hi
+		if len(loDigits) < 20 {
+			maxSameLength, _ := strconv.ParseUint(strings.Repeat("9", len(loDigits)), 10, 64)
+			if maxSameLength < hi {
+				end = maxSameLength
+			}
+		}
+
+		retval = append(
+			retval,
+			buildSameLengthRangeRegex(loDigits, strconv.FormatUint(end, 10))...,
+		)
+
+		if end == hi {
+			return retval
+		}
+		lo = end + 1
+	}
+}
+
+// buildSameLengthRangeRegex returns a list of regexes that, between
+// them, match any number between lo and hi. lo and hi must have the
+// same number of digits.
+func buildSameLengthRangeRegex(lo, hi string) []string {
+	if lo == hi {
+		return []string{lo}
+	}
+
+	// deal with any common leading digit
+	if lo[0] == hi[0] {
+		var retval []string
+		for _, tail := range buildSameLengthRangeRegex(lo[1:], hi[1:]) {
+			retval = append(retval, lo[:1]+tail)
+		}
+		return retval
+	}
+
+	rest := len(lo) - 1
+	lowest := strings.Repeat("0", rest)
+	highest := strings.Repeat("9", rest)
+
+	var retval []string
+	var tail []string
+
+	// lo's first digit, where the rest of lo isn't all zeros
+	loFirst := lo[0]
+	if lo[1:] != lowest {
+		for _, t := range buildSameLengthRangeRegex(lo[1:], highest) {
+			retval = append(retval, lo[:1]+t)
+		}
+		loFirst++
+	}
+
+	// hi's first digit, where the rest of hi isn't all nines
+	hiFirst := hi[0]
+	if hi[1:] != highest {
+		for _, t := range buildSameLengthRangeRegex(lowest, hi[1:]) {
+			tail = append(tail, hi[:1]+t)
+		}
+		hiFirst--
+	}
+
+	// everything in between
+	if loFirst <= hiFirst {
+		middle := string(loFirst)
+		if loFirst < hiFirst {
+			middle = "[" + string(loFirst) + "-" + string(hiFirst) + "]"
+		}
+		switch rest {
+		case 0:
+		case 1:
+			middle += "[0-9]"
+		default:
+			middle += "[0-9]{" + strconv.Itoa(rest) + "}"
+		}
+		retval = append(retval, middle)
+	}
+
+	return append(retval, tail...)
+}
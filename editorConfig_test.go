@@ -0,0 +1,303 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGlobParsesEditorConfigSyntax(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	pattern := "a/**/*.{js,py}"
+	expectedResult := []parsedPattern{
+		{
+			pattern:     "a",
+			patternType: patternTypeStatic,
+		},
+		{
+			pattern:     "/**/",
+			patternType: patternTypeGlobStar,
+		},
+		{
+			pattern:     "*",
+			patternType: patternTypeSegmentMultiMatch,
+		},
+		{
+			pattern:     "\\.",
+			patternType: patternTypeStatic,
+		},
+		{
+			pattern:     "{js,py}",
+			patternType: patternTypeAlternatives,
+			alternatives: [][]parsedPattern{
+				{
+					{
+						pattern:     "js",
+						patternType: patternTypeStatic,
+					},
+				},
+				{
+					{
+						pattern:     "py",
+						patternType: patternTypeStatic,
+					},
+				},
+			},
+		},
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	g := NewGlob(pattern, EditorConfigSyntax)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, g.patternParts)
+	assert.Equal(t, pattern, g.Pattern())
+}
+
+func TestEditorConfigSyntaxMatches(t *testing.T) {
+	t.Parallel()
+
+	// many of these come from the EditorConfig core test suite
+	testDataSet := []testDataStruct{
+		// '*' and '?' stop at '/'
+		{pattern: "a*e.c", input: "ace.c", expectedSuccess: true},
+		{pattern: "a*e.c", input: "abcde.c", expectedSuccess: true},
+		{pattern: "a*e.c", input: "a/e.c", expectedSuccess: false},
+		{pattern: "som?.c", input: "some.c", expectedSuccess: true},
+		{pattern: "som?.c", input: "som/.c", expectedSuccess: false},
+		// '**' crosses '/'
+		{pattern: "a**z.c", input: "a/z.c", expectedSuccess: true},
+		{pattern: "a**z.c", input: "amnz.c", expectedSuccess: true},
+		{pattern: "a**z.c", input: "am/nz.c", expectedSuccess: true},
+		{pattern: "b/**z.c", input: "b/z.c", expectedSuccess: true},
+		{pattern: "b/**z.c", input: "b/mnz.c", expectedSuccess: true},
+		{pattern: "c**/z.c", input: "c/z.c", expectedSuccess: true},
+		{pattern: "c**/z.c", input: "cmn/mnz.c", expectedSuccess: false},
+		// '/**/' matches zero or more folders
+		{pattern: "d/**/z.c", input: "d/z.c", expectedSuccess: true},
+		{pattern: "d/**/z.c", input: "d/mn/z.c", expectedSuccess: true},
+		{pattern: "d/**/z.c", input: "d/mn/op/z.c", expectedSuccess: true},
+		{pattern: "d/**/z.c", input: "dz.c", expectedSuccess: false},
+		// bracket expressions
+		{pattern: "[ab].a", input: "a.a", expectedSuccess: true},
+		{pattern: "[!ab].b", input: "c.b", expectedSuccess: true},
+		{pattern: "[!ab].b", input: "a.b", expectedSuccess: false},
+		{pattern: "ab[e/]cd.i", input: "ab[e/]cd.i", expectedSuccess: true},
+		{pattern: "ab[e/]cd.i", input: "abecd.i", expectedSuccess: false},
+		// alternatives
+		{pattern: "*.{py,js}", input: "main.py", expectedSuccess: true},
+		{pattern: "*.{py,js}", input: "main.js", expectedSuccess: true},
+		{pattern: "*.{py,js}", input: "main.go", expectedSuccess: false},
+		{pattern: "{word,{also},this}.g", input: "word.g", expectedSuccess: true},
+		{pattern: "{word,{also},this}.g", input: "{also}.g", expectedSuccess: true},
+		{pattern: "{word,{also},this}.g", input: "this.g", expectedSuccess: true},
+		{pattern: "{,a,{b}}.h", input: ".h", expectedSuccess: true},
+		{pattern: "{,a,{b}}.h", input: "a.h", expectedSuccess: true},
+		{pattern: "{,a,{b}}.h", input: "{b}.h", expectedSuccess: true},
+		{pattern: "{a,{b,c}}.k", input: "c.k", expectedSuccess: true},
+		{pattern: "{a,*.x}.l", input: "y.x.l", expectedSuccess: true},
+		// braces that aren't alternatives are normal characters
+		{pattern: "{single}.b", input: "{single}.b", expectedSuccess: true},
+		{pattern: "{}.c", input: "{}.c", expectedSuccess: true},
+		{pattern: "{test.j", input: "{test.j", expectedSuccess: true},
+		{pattern: "test}.k", input: "test}.k", expectedSuccess: true},
+		{pattern: "{aardvark..antelope}", input: "{aardvark..antelope}", expectedSuccess: true},
+		// numeric ranges
+		{pattern: "{3..120}", input: "3", expectedSuccess: true},
+		{pattern: "{3..120}", input: "15", expectedSuccess: true},
+		{pattern: "{3..120}", input: "60", expectedSuccess: true},
+		{pattern: "{3..120}", input: "120", expectedSuccess: true},
+		{pattern: "{3..120}", input: "1", expectedSuccess: false},
+		{pattern: "{3..120}", input: "121", expectedSuccess: false},
+		{pattern: "{3..120}", input: "1000", expectedSuccess: false},
+		{pattern: "{3..120}", input: "060", expectedSuccess: true},
+		{pattern: "{-5..5}", input: "-5", expectedSuccess: true},
+		{pattern: "{-5..5}", input: "0", expectedSuccess: true},
+		{pattern: "{-5..5}", input: "-0", expectedSuccess: true},
+		{pattern: "{-5..5}", input: "+5", expectedSuccess: true},
+		{pattern: "{-5..5}", input: "-6", expectedSuccess: false},
+		{pattern: "{-5..5}", input: "6", expectedSuccess: false},
+		{pattern: "{-20..-10}", input: "-15", expectedSuccess: true},
+		{pattern: "{-20..-10}", input: "-9", expectedSuccess: false},
+		{pattern: "{-20..-10}", input: "15", expectedSuccess: false},
+		{pattern: "{10..1}", input: "5", expectedSuccess: true},
+		{pattern: "{-9223372036854775808..9223372036854775807}", input: "-9223372036854775808", expectedSuccess: true},
+		{pattern: "{1..99999999999999999999}", input: "{1..99999999999999999999}", expectedSuccess: true},
+		{pattern: "file{1..3}.txt", input: "file2.txt", expectedSuccess: true},
+		{pattern: "file{1..3}.txt", input: "file4.txt", expectedSuccess: false},
+		// escapes
+		{pattern: "\\*.txt", input: "*.txt", expectedSuccess: true},
+		{pattern: "\\*.txt", input: "a.txt", expectedSuccess: false},
+		{pattern: "\\{a,b}", input: "{a,b}", expectedSuccess: true},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern, EditorConfigSyntax)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualSuccess, err := g.Match(testData.input)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, testData)
+		assert.Equal(t, testData.expectedSuccess, actualSuccess, testData)
+	}
+}
+
+func TestBuildNumericRangeRegex(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		lo             int64
+		hi             int64
+		expectedResult string
+	}{
+		{
+			lo:             3,
+			hi:             120,
+			expectedResult: `(?:\+?0*(?:[3-9]|[1-9][0-9]|1[0-1][0-9]|120))`,
+		},
+		{
+			lo:             -5,
+			hi:             5,
+			expectedResult: `(?:\+?0*(?:[0-5])|-0*(?:[1-5])|-0+)`,
+		},
+		{
+			lo:             95,
+			hi:             1005,
+			expectedResult: `(?:\+?0*(?:9[5-9]|[1-9][0-9]{2}|100[0-5]))`,
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := buildNumericRangeRegex(testData.lo, testData.hi)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedResult, actualResult, testData)
+	}
+}
+
+func TestEditorConfigSyntaxPrefixAndSuffixMatches(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern         string
+		input           string
+		method          string
+		expectedPos     int
+		expectedSuccess bool
+	}{
+		// '**' is shortest match, unless it ends the pattern
+		{"a**b", "abab", "MatchShortestPrefix", 2, true},
+		{"a**b", "abab", "MatchLongestPrefix", 4, true},
+		{"a**", "abab", "MatchShortestPrefix", 4, true},
+		{"b**", "abab", "MatchShortestSuffix", 3, true},
+		{"b**", "abab", "MatchLongestSuffix", 1, true},
+		{"**b", "abab", "MatchShortestSuffix", 3, true},
+		{"**b", "abab", "MatchLongestSuffix", 0, true},
+		// '/**/' matches as few folders as it can for shortest match
+		{"d/**/z", "d/z/y/z", "MatchShortestPrefix", 3, true},
+		{"d/**/z", "d/z/y/z", "MatchLongestPrefix", 7, true},
+		{"d/**/z", "d/z/y/z", "MatchShortestSuffix", 0, true},
+		{"d/**/z", "d/z/y/d/z", "MatchShortestSuffix", 6, true},
+		{"d/**/z", "d/z/y/d/z", "MatchLongestSuffix", 0, true},
+		// a '*' at the end of an alternative doesn't end the pattern
+		{"{a*,c}", "abc", "MatchShortestPrefix", 1, true},
+		{"{a*,c}", "abc", "MatchLongestPrefix", 3, true},
+		{"x{a*,c}", "xabc", "MatchShortestPrefix", 2, true},
+		{"{a*,c}", "abc", "MatchShortestSuffix", 2, true},
+		// '**' matches newlines, the same as '*' and '?'
+		{"a**b", "a\nb", "Match", 0, true},
+		{"a**b", "xa\nb", "MatchShortestSuffix", 1, true},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern, EditorConfigSyntax)
+		matchers := map[string]func(string) (int, bool, error){
+			"MatchShortestPrefix": g.MatchShortestPrefix,
+			"MatchLongestPrefix":  g.MatchLongestPrefix,
+			"MatchShortestSuffix": g.MatchShortestSuffix,
+			"MatchLongestSuffix":  g.MatchLongestSuffix,
+		}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		var actualPos int
+		var actualSuccess bool
+		var err error
+		if testData.method == "Match" {
+			actualSuccess, err = g.Match(testData.input)
+		} else {
+			actualPos, actualSuccess, err = matchers[testData.method](testData.input)
+		}
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, testData)
+		assert.Equal(t, testData.expectedSuccess, actualSuccess, testData)
+		if testData.expectedSuccess {
+			assert.Equal(t, testData.expectedPos, actualPos, testData)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Package editorconfig works out which EditorConfig properties apply
+// to a file, using the rules from .editorconfig files.
+//
+// Section headers are compiled into glob.Glob structs, using the
+// glob.EditorConfigSyntax option.
+package editorconfig
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	glob "github.com/ganbarodigital/go_glob"
+)
+
+// File holds the contents of a single .editorconfig file.
+//
+// Call `Parse()` to create your File.
+type File struct {
+	// Dir is the folder that holds the .editorconfig file, relative to
+	// the top of the tree. It is empty for the top-level folder.
+	Dir string
+
+	// Root is `true` if the file contains `root = true`. EditorConfig
+	// ignores any .editorconfig files in the parent folders of a
+	// root file.
+	Root bool
+
+	// Sections holds each [section] from the file, in the order that
+	// they appear in the file.
+	Sections []*Section
+}
+
+// Section is a single [section] from an .editorconfig file.
+type Section struct {
+	// Name is the glob pattern between the square brackets
+	Name string
+
+	// Line is where the section header appears in its file
+	Line int
+
+	// Properties holds each `name = value` line from the section, in
+	// the order that they appear in the file.
+	Properties []Property
+
+	glob *glob.Glob
+}
+
+// Property is a single `name = value` line from an .editorconfig file.
+type Property struct {
+	// Name is always lower case
+	Name string
+
+	// Value is lower case for the properties defined by the
+	// EditorConfig specification, and as-is for any others
+	Value string
+
+	// Line is where the property appears in its file
+	Line int
+}
+
+// knownProperties are the properties defined by the EditorConfig
+// specification. Their values are case-insensitive.
+var knownProperties = map[string]bool{
+	"indent_style":             true,
+	"indent_size":              true,
+	"tab_width":                true,
+	"end_of_line":              true,
+	"charset":                  true,
+	"trim_trailing_whitespace": true,
+	"insert_final_newline":     true,
+	"root":                     true,
+}
+
+// Parse turns the contents of an .editorconfig file into a File.
+//
+// dir is the folder that holds the .editorconfig file, relative to the
+// top of the tree. Use an empty string for the top-level folder.
+//
+// Like the EditorConfig core libraries, Parse quietly skips any lines
+// that it doesn't understand.
+func Parse(dir string, content []byte) *File {
+	retval := File{
+		Dir: strings.Trim(path.Clean("/"+dir), "/"),
+	}
+
+	var section *Section
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "" || line[0] == '#' || line[0] == ';':
+			// blank line or comment
+		case line[0] == '[' && line[len(line)-1] == ']':
+			section = newSection(line[1:len(line)-1], i+1)
+			retval.Sections = append(retval.Sections, section)
+		default:
+			pos := strings.IndexRune(line, '=')
+			if pos < 0 {
+				continue
+			}
+
+			prop := Property{
+				Name:  strings.ToLower(strings.TrimSpace(line[:pos])),
+				Value: strings.TrimSpace(line[pos+1:]),
+				Line:  i + 1,
+			}
+			if knownProperties[prop.Name] {
+				prop.Value = strings.ToLower(prop.Value)
+			}
+
+			// properties before the first section belong to the
+			// file itself, and `root` is the only one that means
+			// anything
+			if section == nil {
+				if prop.Name == "root" {
+					retval.Root = prop.Value == "true"
+				}
+				continue
+			}
+			section.Properties = append(section.Properties, prop)
+		}
+	}
+
+	return &retval
+}
+
+// newSection creates a Section, and compiles its glob pattern
+func newSection(name string, line int) *Section {
+	pattern := name
+
+	if !hasPathSeparator(name) {
+		// a pattern without a '/' matches a name at any depth
+		pattern = "/**/" + name
+	} else if !strings.HasPrefix(name, "/") {
+		// any other pattern is anchored to the folder that holds
+		// the .editorconfig file
+		pattern = "/" + name
+	}
+
+	retval := Section{
+		Name: name,
+		Line: line,
+		glob: glob.NewGlob(pattern, glob.EditorConfigSyntax),
+	}
+
+	// compile the glob now, so that it is safe to share
+	retval.glob.Match("")
+
+	return &retval
+}
+
+// hasPathSeparator returns `true` if name contains a '/' that isn't
+// inside square brackets
+func hasPathSeparator(name string) bool {
+	inBrackets := false
+	for i := 0; i < len(name); i++ {
+		switch name[i] {
+		case '\\':
+			i++
+		case '[':
+			inBrackets = true
+		case ']':
+			inBrackets = false
+		case '/':
+			if !inBrackets {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Match returns `true` if the section applies to the given path.
+//
+// path must be a slash-separated path, relative to the folder that
+// holds the section's .editorconfig file.
+func (s *Section) Match(path string) bool {
+	// sections that don't compile never match
+	success, _ := s.glob.Match("/" + path)
+	return success
+}
+
+// Resolve returns the EditorConfig properties that apply to the given
+// file.
+//
+// path must be a slash-separated path, relative to the top of the tree.
+// files can be in any order. Resolve ignores any files that aren't in
+// one of path's parent folders, and any files that are above the
+// nearest root file.
+//
+// Sections in deeper files win over sections in shallower files, and
+// later sections win over earlier sections in the same file. A value of
+// `unset` removes the property.
+func Resolve(name string, files []*File) map[string]string {
+	name = strings.Trim(path.Clean("/"+name), "/")
+
+	// we only want the files that apply to this path
+	var applicable []*File
+	for _, file := range files {
+		if file.Dir == "" || strings.HasPrefix(name, file.Dir+"/") {
+			applicable = append(applicable, file)
+		}
+	}
+
+	// shallowest file first, so that deeper files win
+	sort.SliceStable(applicable, func(i, j int) bool {
+		return depth(applicable[i].Dir) < depth(applicable[j].Dir)
+	})
+	for i := len(applicable) - 1; i >= 0; i-- {
+		if applicable[i].Root {
+			applicable = applicable[i:]
+			break
+		}
+	}
+
+	retval := map[string]string{}
+	for _, file := range applicable {
+		rel := name
+		if file.Dir != "" {
+			rel = name[len(file.Dir)+1:]
+		}
+
+		for _, section := range file.Sections {
+			if !section.Match(rel) {
+				continue
+			}
+			for _, prop := range section.Properties {
+				retval[prop.Name] = prop.Value
+			}
+		}
+	}
+
+	for propName, value := range retval {
+		if value == "unset" {
+			delete(retval, propName)
+		}
+	}
+	applyDefaults(retval)
+
+	return retval
+}
+
+// depth returns how many folders deep dir is
+func depth(dir string) int {
+	if dir == "" {
+		return 0
+	}
+
+	return strings.Count(dir, "/") + 1
+}
+
+// applyDefaults fills in the indentation properties that the
+// EditorConfig specification says can be worked out from the others
+func applyDefaults(props map[string]string) {
+	indentSize, hasIndentSize := props["indent_size"]
+	tabWidth, hasTabWidth := props["tab_width"]
+
+	if props["indent_style"] == "tab" && !hasIndentSize {
+		props["indent_size"] = "tab"
+		indentSize, hasIndentSize = "tab", true
+	}
+
+	if hasIndentSize && indentSize != "tab" && !hasTabWidth {
+		props["tab_width"] = indentSize
+	}
+
+	if indentSize == "tab" && hasTabWidth {
+		props["indent_size"] = tabWidth
+	}
+}
+
+// ResolveFS returns the EditorConfig properties that apply to the given
+// file in fsys.
+//
+// It reads the .editorconfig file in each of the file's parent folders,
+// working up towards the top of fsys. It stops when it finds a root
+// file.
+func ResolveFS(fsys fs.FS, name string) (map[string]string, error) {
+	name = strings.Trim(path.Clean("/"+name), "/")
+
+	var files []*File
+	dir := path.Dir(name)
+	for {
+		content, err := fs.ReadFile(fsys, path.Join(dir, ".editorconfig"))
+		if err == nil {
+			file := Parse(dir, content)
+			files = append(files, file)
+			if file.Root {
+				break
+			}
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		if dir == "." {
+			break
+		}
+		dir = path.Dir(dir)
+	}
+
+	return Resolve(name, files), nil
+}
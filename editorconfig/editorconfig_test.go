@@ -0,0 +1,281 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package editorconfig
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReadsSectionsAndProperties(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	content := []byte(`# top-most EditorConfig file
+root = true
+
+[*]
+End_Of_Line = LF
+; a comment
+this line is ignored
+
+[*.{js,py}]
+indent_style = space
+My_Property = Keep Case
+`)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Parse("sub/", content)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, "sub", actualResult.Dir)
+	assert.True(t, actualResult.Root)
+	assert.Len(t, actualResult.Sections, 2)
+
+	assert.Equal(t, "*", actualResult.Sections[0].Name)
+	assert.Equal(t, 4, actualResult.Sections[0].Line)
+	assert.Equal(
+		t,
+		[]Property{{Name: "end_of_line", Value: "lf", Line: 5}},
+		actualResult.Sections[0].Properties,
+	)
+
+	assert.Equal(t, "*.{js,py}", actualResult.Sections[1].Name)
+	assert.Equal(
+		t,
+		[]Property{
+			{Name: "indent_style", Value: "space", Line: 10},
+			{Name: "my_property", Value: "Keep Case", Line: 11},
+		},
+		actualResult.Sections[1].Properties,
+	)
+}
+
+func TestSectionMatch(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		section         string
+		path            string
+		expectedSuccess bool
+	}{
+		// no '/' - matches at any depth
+		{"*.js", "main.js", true},
+		{"*.js", "lib/deep/main.js", true},
+		{"*.js", "main.go", false},
+		{"Makefile", "sub/Makefile", true},
+		// '/' anchors the pattern
+		{"lib/*.js", "lib/main.js", true},
+		{"lib/*.js", "src/lib/main.js", false},
+		{"lib/*.js", "lib/deep/main.js", false},
+		{"/*.js", "main.js", true},
+		{"/*.js", "lib/main.js", false},
+		{"lib/**.js", "lib/deep/main.js", true},
+		{"lib/**/*.js", "lib/main.js", true},
+		// a '/' inside brackets doesn't count
+		{"a[/]b", "x/a[/]b", true},
+		// alternatives and ranges
+		{"{package.json,.travis.yml}", "package.json", true},
+		{"{package.json,.travis.yml}", "deep/.travis.yml", true},
+		{"test{1..3}.c", "test2.c", true},
+		{"test{1..3}.c", "test4.c", false},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		s := newSection(testData.section, 1)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualSuccess := s.Match(testData.path)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedSuccess, actualSuccess, testData)
+	}
+}
+
+func TestResolveAppliesPrecedenceRules(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	files := []*File{
+		Parse("lib", []byte("[*.js]\nindent_size = 4\n[vendor/*.js]\ncharset = unset\n")),
+		Parse("", []byte("[*]\ncharset = utf-8\nindent_size = 2\nend_of_line = lf\n[*.js]\nquote_type = single\n")),
+		Parse("other", []byte("[*]\nindent_size = 8\n")),
+	}
+	expectedResult := map[string]string{
+		"end_of_line": "lf",
+		"indent_size": "4",
+		"tab_width":   "4",
+		"quote_type":  "single",
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Resolve("lib/vendor/jquery.js", files)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestResolveStopsAtRootFile(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	files := []*File{
+		Parse("", []byte("[*]\ncharset = utf-8\n")),
+		Parse("sub", []byte("root = true\n[*]\nend_of_line = crlf\n")),
+	}
+	expectedResult := map[string]string{
+		"end_of_line": "crlf",
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Resolve("sub/main.c", files)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestResolveFillsInIndentationDefaults(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		content        string
+		expectedResult map[string]string
+	}{
+		{
+			content: "[*]\nindent_style = tab\n",
+			expectedResult: map[string]string{
+				"indent_style": "tab",
+				"indent_size":  "tab",
+			},
+		},
+		{
+			content: "[*]\nindent_style = tab\ntab_width = 8\n",
+			expectedResult: map[string]string{
+				"indent_style": "tab",
+				"indent_size":  "8",
+				"tab_width":    "8",
+			},
+		},
+		{
+			content: "[*]\nindent_size = 2\n",
+			expectedResult: map[string]string{
+				"indent_size": "2",
+				"tab_width":   "2",
+			},
+		},
+		{
+			content: "[*]\nindent_size = 2\ntab_width = 4\n",
+			expectedResult: map[string]string{
+				"indent_size": "2",
+				"tab_width":   "4",
+			},
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		files := []*File{Parse("", []byte(testData.content))}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := Resolve("main.c", files)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedResult, actualResult, testData.content)
+	}
+}
+
+func TestResolveFSReadsParentFolders(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fsys := fstest.MapFS{
+		".editorconfig":           {Data: []byte("[*]\ncharset = latin1\n")},
+		"repo/.editorconfig":      {Data: []byte("root = true\n[*]\nindent_style = space\n[*.go]\nindent_style = tab\n")},
+		"repo/cmd/.editorconfig":  {Data: []byte("[main.go]\ntab_width = 4\n")},
+		"repo/cmd/tool/main.go":   {},
+		"repo/cmd/tool/README.md": {},
+	}
+	expectedResult := map[string]string{
+		"indent_style": "tab",
+		"indent_size":  "4",
+		"tab_width":    "4",
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := ResolveFS(fsys, "repo/cmd/tool/main.go")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
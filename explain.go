@@ -0,0 +1,279 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Explanation describes how a Glob has understood its pattern, and
+// how each Match method will use it.
+//
+// Call `Glob.Explain()` to create your Explanation.
+type Explanation struct {
+	// Pattern is the original glob pattern
+	Pattern string
+
+	// Nodes is what the pattern was parsed into
+	Nodes []ExplainedNode
+
+	// Modes holds one entry for each Match method
+	Modes []ExplainedMode
+}
+
+// ExplainedNode is one part of a parsed glob pattern.
+type ExplainedNode struct {
+	// Type says what kind of node this is, such as "static" or "multi"
+	Type string
+
+	// Pattern is the part of the glob pattern that this node came from.
+	// For "static" nodes, it is the regex that matches the node's text.
+	Pattern string
+
+	// Alternatives holds the choices for an "alternatives" node
+	Alternatives [][]ExplainedNode
+}
+
+// ExplainedMode describes how one of the Match methods works.
+type ExplainedMode struct {
+	// Method is the name of the Match method, such as "MatchLongestPrefix"
+	Method string
+
+	// Flags is the combination of Glob* flags that the method uses
+	Flags int
+
+	// Regex is the regex that we build for the method
+	Regex string
+
+	// Engine describes how the method uses the regex, including any
+	// fast path that it takes first
+	Engine string
+
+	// MinInputBytes is the fast path's threshold. The method turns
+	// down any input shorter than this without running the regex. It
+	// is zero if every input goes to the regex.
+	MinInputBytes int
+}
+
+// MatchExplanation says how far Match() got through an input string.
+//
+// Call `Glob.ExplainMatch()` to create your MatchExplanation.
+type MatchExplanation struct {
+	// Input is the string that we tried to match
+	Input string
+
+	// Matched is `true` if Match() returns `true` for Input
+	Matched bool
+
+	// Node is the index of the first node that could not match. It is
+	// equal to the number of nodes if every node matched, but there
+	// was input left over.
+	Node int
+
+	// Offset is how much of Input the nodes before Node matched
+	Offset int
+}
+
+// explainedModes lists each Match method, in the order that they appear
+// in our docs
+var explainedModes = []ExplainedMode{
+	{
+		Method: "Match",
+		Flags:  GlobMatchWholeString,
+		Engine: "regexp, must match the whole input",
+	},
+	{
+		Method: "MatchShortestPrefix",
+		Flags:  GlobAnchorPrefix + GlobShortestMatch,
+		Engine: "regexp, leftmost match",
+	},
+	{
+		Method: "MatchLongestPrefix",
+		Flags:  GlobAnchorPrefix + GlobLongestMatch,
+		Engine: "regexp, leftmost match",
+	},
+	{
+		Method: "MatchShortestSuffix",
+		Flags:  GlobAnchorSuffix + GlobShortestMatch,
+		Engine: "regexp, leftmost match, then searches again after each match for a shorter suffix",
+	},
+	{
+		Method: "MatchLongestSuffix",
+		Flags:  GlobAnchorSuffix + GlobLongestMatch,
+		Engine: "regexp, leftmost match",
+	},
+}
+
+// patternTypeNames maps our internal pattern types onto the names
+// that we use in an Explanation
+var patternTypeNames = map[int]string{
+	patternTypeStatic:             "static",
+	patternTypeSingleMatch:        "single",
+	patternTypeMultiMatch:         "multi",
+	patternTypeSegmentSingleMatch: "segment-single",
+	patternTypeSegmentMultiMatch:  "segment-multi",
+	patternTypeGlobStar:           "globstar",
+	patternTypeAlternatives:       "alternatives",
+	patternTypeNumericRange:       "numeric-range",
+}
+
+// Explain describes how the Glob has parsed its pattern, and the regex
+// that each Match method will use.
+//
+// It always returns a complete Explanation. It also returns an error if
+// the regexes do not compile, in the same way that the Match methods do.
+func (g *Glob) Explain() (Explanation, error) {
+	retval := Explanation{
+		Pattern: g.pattern,
+		Nodes:   explainNodes(g.patternParts),
+	}
+
+	minInputBytes := g.minInputBytes()
+
+	var firstErr error
+	for _, mode := range explainedModes {
+		mode.Regex = buildRegex(g.patternParts, mode.Flags)
+		if minInputBytes > 0 {
+			mode.MinInputBytes = minInputBytes
+			mode.Engine = fmt.Sprintf(
+				"turns down inputs shorter than %d bytes without running the regex, then %s",
+				minInputBytes,
+				mode.Engine,
+			)
+		}
+		retval.Modes = append(retval.Modes, mode)
+
+		_, err := g.getCompiledGlobForFlags(mode.Flags)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	// all done
+	return retval, firstErr
+}
+
+// explainNodes turns parsed patterns into ExplainedNodes
+func explainNodes(parts []parsedPattern) []ExplainedNode {
+	retval := make([]ExplainedNode, 0, len(parts))
+	for _, part := range parts {
+		node := ExplainedNode{
+			Type:    patternTypeNames[part.patternType],
+			Pattern: part.pattern,
+		}
+		for _, alternative := range part.alternatives {
+			node.Alternatives = append(node.Alternatives, explainNodes(alternative))
+		}
+		retval = append(retval, node)
+	}
+
+	return retval
+}
+
+// ExplainMatch works out how far Match() gets through the input string.
+//
+// If the input does not match, it finds the longest run of nodes, from
+// the start of the pattern, that match the start of the input. The
+// node after that run is the one that failed.
+//
+// Returns an error if the Glob's pattern cannot be compiled into a
+// regex.
+func (g *Glob) ExplainMatch(input string) (MatchExplanation, error) {
+	retval := MatchExplanation{
+		Input: input,
+	}
+
+	success, err := g.Match(input)
+	if err != nil {
+		return retval, err
+	}
+	if success {
+		retval.Matched = true
+		retval.Node = len(g.patternParts)
+		retval.Offset = len(input)
+		return retval, nil
+	}
+
+	// the empty run of nodes always matches, so this loop always
+	// finds an answer
+	for n := len(g.patternParts); n >= 0; n-- {
+		rawRegex := buildRegex(g.patternParts[:n], GlobAnchorPrefix+GlobLongestMatch)
+		regex, err := regexp.Compile(rawRegex)
+		if err != nil {
+			return retval, err
+		}
+		regex.Longest()
+
+		loc := regex.FindStringIndex(input)
+		if loc != nil {
+			retval.Node = n
+			retval.Offset = loc[1]
+			break
+		}
+	}
+
+	// all done
+	return retval, nil
+}
+
+// String returns a human-readable version of the Explanation.
+func (e Explanation) String() string {
+	retval := strings.Builder{}
+
+	fmt.Fprintf(&retval, "pattern: %s\n", e.Pattern)
+	retval.WriteString("nodes:\n")
+	writeExplainedNodes(&retval, e.Nodes, "  ")
+	retval.WriteString("modes:\n")
+	for _, mode := range e.Modes {
+		fmt.Fprintf(&retval, "  %s\n", mode.Method)
+		fmt.Fprintf(&retval, "    regex:  %s\n", mode.Regex)
+		fmt.Fprintf(&retval, "    engine: %s\n", mode.Engine)
+	}
+
+	return retval.String()
+}
+
+// writeExplainedNodes adds one line per node to the given buffer
+func writeExplainedNodes(buf *strings.Builder, nodes []ExplainedNode, indent string) {
+	for i, node := range nodes {
+		fmt.Fprintf(buf, "%s%d: %-14s %s\n", indent, i, node.Type, node.Pattern)
+		for j, alternative := range node.Alternatives {
+			fmt.Fprintf(buf, "%s   alternative %d:\n", indent, j)
+			writeExplainedNodes(buf, alternative, indent+"     ")
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobExplainDescribesNodesAndModes(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	g := NewGlob("*.go")
+	expectedNodes := []ExplainedNode{
+		{
+			Type:    "multi",
+			Pattern: "*",
+		},
+		{
+			Type:    "static",
+			Pattern: "\\.go",
+		},
+	}
+	expectedRegexes := map[string]string{
+		"Match":               "^.*?\\.go$",
+		"MatchShortestPrefix": "^.*?\\.go",
+		"MatchLongestPrefix":  "^.*\\.go",
+		"MatchShortestSuffix": ".*?\\.go$",
+		"MatchLongestSuffix":  ".*\\.go$",
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := g.Explain()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "*.go", actualResult.Pattern)
+	assert.Equal(t, expectedNodes, actualResult.Nodes)
+
+	actualRegexes := map[string]string{}
+	for _, mode := range actualResult.Modes {
+		actualRegexes[mode.Method] = mode.Regex
+		assert.NotEmpty(t, mode.Engine)
+	}
+	assert.Equal(t, expectedRegexes, actualRegexes)
+}
+
+func TestGlobExplainReportsMinLengthFastPath(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern               string
+		expectedMinInputBytes int
+	}{
+		{pattern: "???-????", expectedMinInputBytes: 8},
+		{pattern: "src/*.go", expectedMinInputBytes: 7},
+		{pattern: "*", expectedMinInputBytes: 0},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, err := g.Explain()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err)
+		assert.Len(t, actualResult.Modes, 5)
+		for _, mode := range actualResult.Modes {
+			assert.Equal(t, testData.expectedMinInputBytes, mode.MinInputBytes, testData.pattern+" "+mode.Method)
+			if testData.expectedMinInputBytes > 0 {
+				assert.Contains(
+					t,
+					mode.Engine,
+					fmt.Sprintf("turns down inputs shorter than %d bytes without running the regex, then regexp", testData.expectedMinInputBytes),
+					testData.pattern+" "+mode.Method,
+				)
+			} else {
+				assert.True(t, strings.HasPrefix(mode.Engine, "regexp"), testData.pattern+" "+mode.Method)
+			}
+		}
+	}
+}
+
+func TestGlobExplainDescribesAlternatives(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	g := NewGlob("{a,b*}.{1..3}", EditorConfigSyntax)
+	expectedNodes := []ExplainedNode{
+		{
+			Type:    "alternatives",
+			Pattern: "{a,b*}",
+			Alternatives: [][]ExplainedNode{
+				{
+					{Type: "static", Pattern: "a"},
+				},
+				{
+					{Type: "static", Pattern: "b"},
+					{Type: "segment-multi", Pattern: "*"},
+				},
+			},
+		},
+		{
+			Type:    "static",
+			Pattern: "\\.",
+		},
+		{
+			Type:    "numeric-range",
+			Pattern: "{1..3}",
+		},
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := g.Explain()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedNodes, actualResult.Nodes)
+	assert.Contains(t, actualResult.String(), "alternative 1:\n")
+}
+
+func TestGlobExplainReturnsExplanationAndErrorWhenRegexInvalid(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// this pattern is invalid because of the mismatched '['
+	g := NewGlob("12345[")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := g.Explain()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Error(t, err)
+	assert.Len(t, actualResult.Modes, 5)
+	assert.Equal(t, "^12345[$", actualResult.Modes[0].Regex)
+}
+
+func TestGlobExplainMatchFindsWhereMatchingFailed(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		input          string
+		expectedResult MatchExplanation
+	}{
+		{
+			"src/main.go",
+			MatchExplanation{Input: "src/main.go", Matched: true, Node: 3, Offset: 11},
+		},
+		{
+			"lib/main.go",
+			MatchExplanation{Input: "lib/main.go", Node: 0, Offset: 0},
+		},
+		{
+			"src/main.c",
+			MatchExplanation{Input: "src/main.c", Node: 2, Offset: 10},
+		},
+		{
+			"src/main.go.orig",
+			MatchExplanation{Input: "src/main.go.orig", Node: 3, Offset: 11},
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob("src/*.go")
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, err := g.ExplainMatch(testData.input)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err)
+		assert.Equal(t, testData.expectedResult, actualResult)
+	}
+}
+
+func TestGlobExplainMatchReturnsErrorWhenRegexInvalid(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// this pattern is invalid because of the mismatched '['
+	g := NewGlob("12345[")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err := g.ExplainMatch("12345")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Error(t, err)
+}
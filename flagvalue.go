@@ -0,0 +1,155 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+// Flag is a flag.Value that holds a single Glob. The Glob is compiled,
+// and checked, when the flag is parsed.
+//
+//	include := glob.NewFlag()
+//	flag.Var(include, "include", "only process files that match this pattern")
+//
+// The zero value is ready to use, and uses UNIX shell syntax.
+type Flag struct {
+	// Glob holds the Glob for the last value of the flag. It is nil
+	// if the flag has not been set.
+	Glob *Glob
+
+	options []func(*Glob)
+}
+
+// NewFlag creates a Flag. Any options are passed to NewGlob() when the
+// flag is set.
+func NewFlag(options ...func(*Glob)) *Flag {
+	return &Flag{options: options}
+}
+
+// Set replaces the flag's Glob with a Glob for the given pattern. It
+// returns a *SyntaxError if the pattern does not compile, or a
+// *LimitError if the pattern breaks one of the flag's limits.
+//
+// It meets the flag.Value interface.
+func (f *Flag) Set(value string) error {
+	g, err := Compile(value, f.options...)
+	if err != nil {
+		return err
+	}
+
+	f.Glob = g
+	return nil
+}
+
+// String returns the pattern of the flag's Glob.
+//
+// It meets the flag.Value interface.
+func (f *Flag) String() string {
+	if f == nil || f.Glob == nil {
+		return ""
+	}
+
+	return f.Glob.pattern
+}
+
+// Get returns the flag's Glob.
+//
+// It meets the flag.Getter interface.
+func (f *Flag) Get() interface{} {
+	return f.Glob
+}
+
+// ListFlag is a flag.Value that holds a list of Globs. Each time the
+// flag is set, its Globs are added to the list. Each value can also be
+// a comma-separated list of patterns, e.g. `--include '*.go,*.mod'`.
+// Commas inside `[...]` and `{...}`, and escaped commas, don't split
+// the list.
+//
+//	includes := glob.NewListFlag()
+//	flag.Var(includes, "include", "only process files that match these patterns")
+//
+// The zero value is ready to use, and uses UNIX shell syntax.
+type ListFlag struct {
+	// Globs holds a Glob for each pattern that the flag has been set
+	// to, in order
+	Globs Globs
+}
+
+// NewListFlag creates a ListFlag. Any options are passed to NewGlob()
+// for each pattern.
+func NewListFlag(options ...func(*Glob)) *ListFlag {
+	return &ListFlag{Globs: *NewGlobs(options...)}
+}
+
+// Set adds a Glob for each pattern in the given comma-separated list.
+// It returns a *SyntaxError if any of the patterns do not compile, or
+// a *LimitError if any of them break one of the flag's limits. When it
+// returns an error, none of the patterns are added.
+//
+// It meets the flag.Value interface.
+func (f *ListFlag) Set(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	var globs []*Glob
+	for _, pattern := range splitPatternList(value) {
+		g, err := f.Globs.newGlob(pattern)
+		if err != nil {
+			return err
+		}
+		globs = append(globs, g)
+	}
+
+	f.Globs.List = append(f.Globs.List, globs...)
+	return nil
+}
+
+// String returns the patterns of the flag's Globs, as a comma-separated
+// list.
+//
+// It meets the flag.Value interface.
+func (f *ListFlag) String() string {
+	if f == nil {
+		return ""
+	}
+
+	text, _ := f.Globs.MarshalText()
+	return string(text)
+}
+
+// Get returns the flag's Globs.
+//
+// It meets the flag.Getter interface.
+func (f *ListFlag) Get() interface{} {
+	return f.Globs
+}
@@ -0,0 +1,222 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFlagSetForTest creates a FlagSet that returns errors instead of
+// exiting
+func newFlagSetForTest() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	return fs
+}
+
+func TestFlagSetCompilesPattern(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fs := newFlagSetForTest()
+	include := NewFlag()
+	fs.Var(include, "include", "")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := fs.Parse([]string{"--include", "*.go", "--include", "*.mod"})
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+
+	// the last value wins
+	assert.Equal(t, "*.mod", include.String())
+	assert.Same(t, include.Glob, include.Get())
+
+	success, err := include.Glob.Match("go.mod")
+	assert.Nil(t, err)
+	assert.True(t, success)
+}
+
+func TestFlagSetReturnsErrorForInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	include := &Flag{}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := include.Set("ab[z-a]")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	var syntaxErr *SyntaxError
+	if assert.True(t, errors.As(err, &syntaxErr)) {
+		assert.Equal(t, 2, syntaxErr.Offset)
+	}
+	assert.Nil(t, include.Glob)
+}
+
+func TestFlagPassesOptionsToNewGlob(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	include := NewFlag(EditorConfigSyntax, MaxWildcards(1))
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := include.Set("*.{go,md}")
+	limitErr := include.Set("*/*.go")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	success, err := include.Glob.Match("README.md")
+	assert.Nil(t, err)
+	assert.True(t, success)
+
+	var actualLimitErr *LimitError
+	assert.True(t, errors.As(limitErr, &actualLimitErr))
+}
+
+func TestFlagZeroValueHasEmptyString(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	var nilFlag *Flag
+	var nilListFlag *ListFlag
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	flagResult := (&Flag{}).String()
+	nilFlagResult := nilFlag.String()
+	listFlagResult := (&ListFlag{}).String()
+	nilListFlagResult := nilListFlag.String()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, "", flagResult)
+	assert.Equal(t, "", nilFlagResult)
+	assert.Equal(t, "", listFlagResult)
+	assert.Equal(t, "", nilListFlagResult)
+}
+
+func TestListFlagSetAddsEachPattern(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fs := newFlagSetForTest()
+	includes := NewListFlag(EditorConfigSyntax)
+	fs.Var(includes, "include", "")
+
+	expectedResult := []string{"*.go", "*.{md,txt}", "[,]*", "a,b", "go.mod"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := fs.Parse([]string{
+		"--include", "*.go",
+		"--include", "*.{md,txt},[,]*,a\\,b",
+		"--include", "",
+		"--include", "go.mod",
+	})
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+
+	var actualResult []string
+	for _, g := range includes.Globs.List {
+		actualResult = append(actualResult, g.Pattern())
+	}
+	assert.Equal(t, expectedResult, actualResult)
+	assert.Equal(t, "*.go,*.{md,txt},[,]*,a\\,b,go.mod", includes.String())
+	assert.Equal(t, includes.Globs, includes.Get())
+
+	success, err := includes.Globs.List[1].Match("notes.txt")
+	assert.Nil(t, err)
+	assert.True(t, success)
+}
+
+func TestListFlagSetAddsNothingWhenAnyPatternIsInvalid(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fs := newFlagSetForTest()
+	includes := &ListFlag{}
+	fs.Var(includes, "include", "")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := fs.Parse([]string{"--include", "*.go", "--include", "*.md,12345["})
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "bad glob pattern '12345[' at offset 5")
+	if assert.Len(t, includes.Globs.List, 1) {
+		assert.Equal(t, "*.go", includes.Globs.List[0].Pattern())
+	}
+}
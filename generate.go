@@ -0,0 +1,369 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// generateAttempts is how many times Generate() and GenerateNonMatching()
+// will try before giving up
+const generateAttempts = 100
+
+// printableChars is what we build wildcard matches from, so that the
+// generated strings are easy to read in test failures
+const printableChars = " !\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstuvwxyz{|}~"
+
+const (
+	genPieceText = iota
+	genPieceAtom
+	genPieceAny
+	genPieceVariable
+)
+
+// genPiece is one part of the string that we are generating
+type genPiece struct {
+	kind int
+
+	// text is used by genPieceText
+	text string
+
+	// atom is used by genPieceAtom
+	atom staticAtom
+
+	// noSlash stops genPieceAny and genPieceVariable from using '/'
+	noSlash bool
+}
+
+// Generate returns a random string that matches the Glob's pattern,
+// and is no more than maxLen bytes long.
+//
+// Wildcards are filled in with printable ASCII characters. The result
+// always satisfies Match().
+//
+// Returns an error if the Glob's pattern cannot be compiled into a
+// regex, or if it cannot make a short enough string.
+func (g *Glob) Generate(rng *rand.Rand, maxLen int) (string, error) {
+	_, err := g.getCompiledGlobForFlags(GlobMatchWholeString)
+	if err != nil {
+		return "", err
+	}
+
+	for attempt := 0; attempt < generateAttempts; attempt++ {
+		pieces, err := planPieces(rng, g.patternParts, nil)
+		if err != nil {
+			return "", err
+		}
+
+		retval, ok := fillPieces(rng, pieces, maxLen)
+		if !ok {
+			continue
+		}
+
+		// belt and braces: we only return strings that we know match
+		success, err := g.Match(retval)
+		if err != nil {
+			return "", err
+		}
+		if success {
+			return retval, nil
+		}
+	}
+
+	return "", fmt.Errorf("glob: cannot generate a string of %d bytes or less that matches '%s'", maxLen, g.pattern)
+}
+
+// GenerateNonMatching returns a random string that is nearly a match
+// for the Glob's pattern, but does not match it. It is no more than
+// maxLen bytes long.
+//
+// It starts from a string that Generate() could have returned, and
+// makes one change to it: a literal character is replaced, a character
+// class is violated, a '?' is dropped, a '/' is added where the pattern
+// does not allow one, or an extra character is added to the end. The
+// result never satisfies Match().
+//
+// Returns an error if the Glob's pattern cannot be compiled into a
+// regex, or if it cannot find a string that doesn't match (for example,
+// because the pattern is `*`).
+func (g *Glob) GenerateNonMatching(rng *rand.Rand, maxLen int) (string, error) {
+	_, err := g.getCompiledGlobForFlags(GlobMatchWholeString)
+	if err != nil {
+		return "", err
+	}
+
+	for attempt := 0; attempt < generateAttempts; attempt++ {
+		pieces, err := planPieces(rng, g.patternParts, nil)
+		if err != nil {
+			return "", err
+		}
+
+		pieces = mutatePieces(rng, pieces)
+		retval, ok := fillPieces(rng, pieces, maxLen)
+		if !ok {
+			continue
+		}
+
+		success, err := g.Match(retval)
+		if err != nil {
+			return "", err
+		}
+		if !success {
+			return retval, nil
+		}
+	}
+
+	return "", fmt.Errorf("glob: cannot generate a string of %d bytes or less that does not match '%s'", maxLen, g.pattern)
+}
+
+// planPieces turns the parsed pattern into a flat list of pieces,
+// picking one choice for each set of alternatives and each numeric
+// range along the way
+func planPieces(rng *rand.Rand, parts []parsedPattern, pieces []genPiece) ([]genPiece, error) {
+	for _, part := range parts {
+		switch part.patternType {
+		case patternTypeStatic:
+			atoms, err := parseStaticAtoms(part.pattern)
+			if err != nil {
+				return nil, err
+			}
+			for _, atom := range atoms {
+				pieces = append(pieces, genPiece{kind: genPieceAtom, atom: atom})
+			}
+		case patternTypeSingleMatch:
+			pieces = append(pieces, genPiece{kind: genPieceAny})
+		case patternTypeMultiMatch:
+			pieces = append(pieces, genPiece{kind: genPieceVariable})
+		case patternTypeSegmentSingleMatch:
+			pieces = append(pieces, genPiece{kind: genPieceAny, noSlash: true})
+		case patternTypeSegmentMultiMatch:
+			pieces = append(pieces, genPiece{kind: genPieceVariable, noSlash: true})
+		case patternTypeGlobStar:
+			if part.pattern != "/**/" {
+				pieces = append(pieces, genPiece{kind: genPieceVariable})
+				break
+			}
+			// '/**/' matches either a single '/', or some folders
+			pieces = append(pieces, literalPiece('/'))
+			if rng.Intn(2) == 0 {
+				pieces = append(pieces, genPiece{kind: genPieceVariable}, literalPiece('/'))
+			}
+		case patternTypeAlternatives:
+			var err error
+			choice := part.alternatives[rng.Intn(len(part.alternatives))]
+			pieces, err = planPieces(rng, choice, pieces)
+			if err != nil {
+				return nil, err
+			}
+		case patternTypeNumericRange:
+			lo, hi, _ := parseNumericRange(part.pattern)
+			for _, r := range strconv.FormatInt(randomInt64(rng, lo, hi), 10) {
+				pieces = append(pieces, literalPiece(r))
+			}
+		}
+	}
+
+	return pieces, nil
+}
+
+// literalPiece returns a piece that matches a single character
+func literalPiece(r rune) genPiece {
+	return genPiece{
+		kind: genPieceAtom,
+		atom: staticAtom{ranges: []rune{r, r}},
+	}
+}
+
+// randomInt64 returns a random number between lo and hi, inclusive
+func randomInt64(rng *rand.Rand, lo, hi int64) int64 {
+	span := uint64(hi - lo)
+	if span >= math.MaxInt64 {
+		for {
+			n := int64(rng.Uint64())
+			if n >= lo && n <= hi {
+				return n
+			}
+		}
+	}
+
+	return lo + rng.Int63n(int64(span)+1)
+}
+
+// fillPieces turns a list of pieces into a string that is no more than
+// maxLen bytes long. It returns `false` if it cannot.
+func fillPieces(rng *rand.Rand, pieces []genPiece, maxLen int) (string, bool) {
+	// fill in the fixed-length pieces first, so that we know how much
+	// room is left for the variable-length ones
+	fixed := make([]string, len(pieces))
+	budget := maxLen
+	for i, piece := range pieces {
+		switch piece.kind {
+		case genPieceText:
+			fixed[i] = piece.text
+		case genPieceAtom:
+			r, ok := randomRuneIn(rng, piece.atom)
+			if !ok {
+				return "", false
+			}
+			fixed[i] = string(r)
+		case genPieceAny:
+			fixed[i] = string(randomPrintable(rng, piece.noSlash))
+		}
+		budget -= len(fixed[i])
+	}
+	if budget < 0 {
+		return "", false
+	}
+
+	retval := strings.Builder{}
+	for i, piece := range pieces {
+		if piece.kind != genPieceVariable {
+			retval.WriteString(fixed[i])
+			continue
+		}
+
+		n := rng.Intn(budget + 1)
+		budget -= n
+		for ; n > 0; n-- {
+			retval.WriteRune(randomPrintable(rng, piece.noSlash))
+		}
+	}
+
+	return retval.String(), true
+}
+
+// randomPrintable returns a random printable ASCII character
+func randomPrintable(rng *rand.Rand, noSlash bool) rune {
+	for {
+		r := rune(printableChars[rng.Intn(len(printableChars))])
+		if !noSlash || r != '/' {
+			return r
+		}
+	}
+}
+
+// randomRuneIn returns a random character that the atom matches. It
+// prefers printable ASCII characters.
+func randomRuneIn(rng *rand.Rand, atom staticAtom) (rune, bool) {
+	if atom.isLiteral() {
+		return atom.ranges[0], true
+	}
+
+	var candidates []rune
+	for _, r := range printableChars {
+		if atom.contains(r) {
+			candidates = append(candidates, r)
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates[rng.Intn(len(candidates))], true
+	}
+
+	// we have to go outside printable ASCII
+	for attempt := 0; attempt < generateAttempts; attempt++ {
+		i := rng.Intn(len(atom.ranges)/2) * 2
+		lo, hi := atom.ranges[i], atom.ranges[i+1]
+		r := lo + rune(rng.Int63n(int64(hi-lo)+1))
+		if r < 0xD800 || r > 0xDFFF {
+			return r, true
+		}
+	}
+
+	return 0, false
+}
+
+// randomPrintableNotIn returns a random printable ASCII character that
+// the atom does not match
+func randomPrintableNotIn(rng *rand.Rand, atom staticAtom) (rune, bool) {
+	var candidates []rune
+	for _, r := range printableChars {
+		if !atom.contains(r) {
+			candidates = append(candidates, r)
+		}
+	}
+	if len(candidates) == 0 {
+		return 0, false
+	}
+
+	return candidates[rng.Intn(len(candidates))], true
+}
+
+// mutatePieces makes one change to the list of pieces, so that the
+// string built from them is unlikely to match the pattern any more
+func mutatePieces(rng *rand.Rand, pieces []genPiece) []genPiece {
+	// where can we make a change?
+	var candidates []int
+	for i, piece := range pieces {
+		switch piece.kind {
+		case genPieceAtom, genPieceAny:
+			candidates = append(candidates, i)
+		case genPieceVariable:
+			if piece.noSlash {
+				candidates = append(candidates, i)
+			}
+		}
+	}
+
+	// we can always try adding an extra character to the end; the
+	// caller checks whether or not it still matches
+	choice := rng.Intn(len(candidates) + 1)
+	if choice == len(candidates) {
+		return append(pieces, genPiece{kind: genPieceAny})
+	}
+
+	i := candidates[choice]
+	retval := append([]genPiece(nil), pieces...)
+	switch pieces[i].kind {
+	case genPieceAtom:
+		r, ok := randomPrintableNotIn(rng, pieces[i].atom)
+		if ok {
+			retval[i] = genPiece{kind: genPieceText, text: string(r)}
+		}
+	case genPieceAny:
+		if pieces[i].noSlash && rng.Intn(2) == 0 {
+			retval[i] = genPiece{kind: genPieceText, text: "/"}
+		} else {
+			retval[i] = genPiece{kind: genPieceText}
+		}
+	case genPieceVariable:
+		retval[i] = genPiece{kind: genPieceText, text: "/"}
+	}
+
+	return retval
+}
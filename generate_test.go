@@ -0,0 +1,201 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobGenerateReturnsMatchingStrings(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern string
+		options []func(*Glob)
+	}{
+		{pattern: ""},
+		{pattern: "0123456789"},
+		{pattern: "0?23?5?7?9"},
+		{pattern: "*.go"},
+		{pattern: "0*2*4*6*8*"},
+		{pattern: "[!b].go"},
+		{pattern: "[[:digit:]][[:upper:]]*"},
+		{pattern: "[*?]\\*"},
+		{pattern: "foo (*)|^$"},
+		{pattern: "[!\x20-\x7e]"},
+		{pattern: "src/**/*.{go,md}", options: []func(*Glob){EditorConfigSyntax}},
+		{pattern: "file{-5..120}.txt", options: []func(*Glob){EditorConfigSyntax}},
+		{pattern: "{-9223372036854775808..9223372036854775807}", options: []func(*Glob){EditorConfigSyntax}},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern, testData.options...)
+		rng := rand.New(rand.NewSource(1))
+		maxLen := 40
+
+		for i := 0; i < 50; i++ {
+			// ----------------------------------------------------------------
+			// perform the change
+
+			actualResult, err := g.Generate(rng, maxLen)
+
+			// ----------------------------------------------------------------
+			// test the results
+
+			assert.Nil(t, err, testData.pattern)
+			assert.LessOrEqual(t, len(actualResult), maxLen, testData.pattern)
+
+			success, err := g.Match(actualResult)
+			assert.Nil(t, err)
+			assert.True(t, success, "pattern %q generated %q", testData.pattern, actualResult)
+		}
+	}
+}
+
+func TestGlobGenerateNonMatchingReturnsStringsThatDoNotMatch(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern string
+		options []func(*Glob)
+	}{
+		{pattern: ""},
+		{pattern: "0123456789"},
+		{pattern: "0?23?5?7?9"},
+		{pattern: "*.go"},
+		{pattern: "[!b].go"},
+		{pattern: "[[:digit:]][[:upper:]]*"},
+		{pattern: "src/**/*.{go,md}", options: []func(*Glob){EditorConfigSyntax}},
+		{pattern: "file{-5..120}.txt", options: []func(*Glob){EditorConfigSyntax}},
+		{pattern: "*", options: []func(*Glob){EditorConfigSyntax}},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern, testData.options...)
+		rng := rand.New(rand.NewSource(1))
+		maxLen := 40
+
+		for i := 0; i < 50; i++ {
+			// ----------------------------------------------------------------
+			// perform the change
+
+			actualResult, err := g.GenerateNonMatching(rng, maxLen)
+
+			// ----------------------------------------------------------------
+			// test the results
+
+			assert.Nil(t, err, testData.pattern)
+			assert.LessOrEqual(t, len(actualResult), maxLen, testData.pattern)
+
+			success, err := g.Match(actualResult)
+			assert.Nil(t, err)
+			assert.False(t, success, "pattern %q generated %q", testData.pattern, actualResult)
+		}
+	}
+}
+
+func TestGlobGenerateReturnsErrorWhenMaxLenTooShort(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	g := NewGlob("0123456789*")
+	rng := rand.New(rand.NewSource(1))
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := g.Generate(rng, 9)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Error(t, err)
+	assert.Empty(t, actualResult)
+}
+
+func TestGlobGenerateNonMatchingReturnsErrorWhenEverythingMatches(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	g := NewGlob("*")
+	rng := rand.New(rand.NewSource(1))
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := g.GenerateNonMatching(rng, 10)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Error(t, err)
+	assert.Empty(t, actualResult)
+}
+
+func TestGlobGenerateReturnsErrorWhenRegexInvalid(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// this pattern is invalid because of the mismatched '['
+	g := NewGlob("12345[")
+	rng := rand.New(rand.NewSource(1))
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	_, err1 := g.Generate(rng, 10)
+	_, err2 := g.GenerateNonMatching(rng, 10)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Error(t, err1)
+	assert.Error(t, err2)
+}
@@ -0,0 +1,404 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Package gitignore decides which paths git would ignore, using the
+// rules from .gitignore files.
+//
+// Every pattern is compiled down to a set of glob.Glob structs, one
+// for each part of the path that it matches against.
+package gitignore
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+
+	glob "github.com/ganbarodigital/go_glob"
+)
+
+// Pattern is a single rule from a .gitignore file.
+//
+// Call `ParsePattern()` or `Parse()` to create your Pattern structs.
+type Pattern struct {
+	// Base is the folder that holds the .gitignore file that this
+	// pattern came from, relative to the top of the tree. It is empty
+	// for the top-level folder.
+	Base string
+
+	// Line is where this pattern appears in its .gitignore file. It
+	// is zero for patterns created by `ParsePattern()`.
+	Line int
+
+	// Text is the pattern, exactly as it appears in the .gitignore file
+	Text string
+
+	negate   bool
+	dirOnly  bool
+	invalid  bool
+	segments []segment
+}
+
+// segment matches a single folder or file name in a path
+type segment struct {
+	glob     *glob.Glob
+	globstar bool
+}
+
+// ParsePattern turns a single line from a .gitignore file into a
+// Pattern.
+//
+// base is the folder that holds the .gitignore file, relative to the
+// top of the tree. Use an empty string for the top-level folder.
+//
+// Returns nil if the line is blank or a comment.
+func ParsePattern(base, line string) *Pattern {
+	text := trimTrailingSpaces(strings.TrimSuffix(line, "\r"))
+	if text == "" || text[0] == '#' {
+		return nil
+	}
+
+	retval := Pattern{
+		Base: strings.Trim(path.Clean("/"+base), "/"),
+		Text: text,
+	}
+
+	// '!' means this pattern re-includes paths that an earlier
+	// pattern excluded
+	if text[0] == '!' {
+		retval.negate = true
+		text = text[1:]
+	}
+
+	// a trailing '/' means this pattern only matches folders
+	if strings.HasSuffix(text, "/") {
+		retval.dirOnly = true
+		text = text[:len(text)-1]
+	}
+
+	if !strings.Contains(text, "/") {
+		// a pattern without a '/' matches a name at any depth
+		retval.segments = []segment{
+			{globstar: true},
+			{glob: glob.NewGlob(text)},
+		}
+	} else {
+		// any other pattern is anchored to the folder that holds
+		// the .gitignore file
+		for _, part := range strings.Split(strings.TrimPrefix(text, "/"), "/") {
+			if part == "**" {
+				retval.segments = append(retval.segments, segment{globstar: true})
+			} else {
+				retval.segments = append(retval.segments, segment{glob: glob.NewGlob(part)})
+			}
+		}
+	}
+
+	// git quietly ignores patterns that it cannot match against, so
+	// we compile everything now to find out which ones those are
+	for _, seg := range retval.segments {
+		if seg.globstar {
+			continue
+		}
+		if _, err := seg.glob.Match(""); err != nil {
+			retval.invalid = true
+		}
+	}
+
+	// all done
+	return &retval
+}
+
+// Parse turns the contents of a .gitignore file into a list of
+// Patterns, in the order that they appear in the file.
+//
+// base is the folder that holds the .gitignore file, relative to the
+// top of the tree. Use an empty string for the top-level folder.
+func Parse(base string, content []byte) []*Pattern {
+	var retval []*Pattern
+
+	// git ignores any UTF-8 byte order mark
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	for i, line := range strings.Split(string(content), "\n") {
+		p := ParsePattern(base, line)
+		if p == nil {
+			continue
+		}
+
+		p.Line = i + 1
+		retval = append(retval, p)
+	}
+
+	return retval
+}
+
+// IsNegated returns `true` if the pattern starts with a '!', and
+// re-includes any paths that it matches.
+func (p *Pattern) IsNegated() bool {
+	return p.negate
+}
+
+// IsDirOnly returns `true` if the pattern ends with a '/', and only
+// matches folders.
+func (p *Pattern) IsDirOnly() bool {
+	return p.dirOnly
+}
+
+// Match returns `true` if the given path matches the pattern. It
+// ignores any leading '!' in the pattern.
+//
+// path must be a slash-separated path, relative to the top of the
+// tree. Set isDir to `true` if the path is a folder.
+//
+// Match does not look at the path's parent folders. Use a Matcher if
+// you need to know whether git would ignore the path.
+func (p *Pattern) Match(path string, isDir bool) bool {
+	if p.invalid || (p.dirOnly && !isDir) {
+		return false
+	}
+
+	// patterns only apply to paths beneath the .gitignore file
+	if p.Base != "" {
+		if !strings.HasPrefix(path, p.Base+"/") {
+			return false
+		}
+		path = path[len(p.Base)+1:]
+	}
+
+	return matchSegments(p.segments, strings.Split(path, "/"))
+}
+
+// matchSegments returns `true` if the list of names satisfies the
+// list of segments
+func matchSegments(segments []segment, names []string) bool {
+	if len(segments) == 0 {
+		return len(names) == 0
+	}
+
+	if segments[0].globstar {
+		// a trailing '**' matches everything inside the folder, but
+		// not the folder itself
+		if len(segments) == 1 {
+			return len(names) > 0
+		}
+
+		// any other '**' matches zero or more folders
+		for i := 0; i <= len(names); i++ {
+			if matchSegments(segments[1:], names[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(names) == 0 {
+		return false
+	}
+
+	// we have already thrown away any patterns that don't compile
+	success, _ := segments[0].glob.Match(names[0])
+	if !success {
+		return false
+	}
+
+	return matchSegments(segments[1:], names[1:])
+}
+
+// trimTrailingSpaces strips any unescaped spaces from the end of the
+// line, the same way that git does
+func trimTrailingSpaces(line string) string {
+	lastSpace := -1
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case ' ':
+			if lastSpace < 0 {
+				lastSpace = i
+			}
+		case '\\':
+			i++
+			if i == len(line) {
+				return line
+			}
+			lastSpace = -1
+		default:
+			lastSpace = -1
+		}
+	}
+
+	if lastSpace >= 0 {
+		return line[:lastSpace]
+	}
+
+	return line
+}
+
+// Matcher holds the patterns from one or more .gitignore files, and
+// applies them the same way that git does.
+//
+// Call `NewMatcher()` to create your Matcher.
+type Matcher struct {
+	patterns []*Pattern
+}
+
+// NewMatcher creates a Matcher that starts with the given patterns
+func NewMatcher(patterns ...*Pattern) *Matcher {
+	retval := Matcher{
+		patterns: patterns,
+	}
+
+	return &retval
+}
+
+// Add appends the patterns from a .gitignore file to the Matcher.
+//
+// Patterns that are added later take priority over patterns that were
+// added earlier. Add the patterns from parent folders before the
+// patterns from their sub-folders.
+func (m *Matcher) Add(base string, content []byte) {
+	m.patterns = append(m.patterns, Parse(base, content)...)
+}
+
+// Patterns returns the list of patterns held by the Matcher, in the
+// order that they are applied.
+func (m *Matcher) Patterns() []*Pattern {
+	return m.patterns
+}
+
+// Ignored returns `true` if git would ignore the given path.
+//
+// path must be a slash-separated path, relative to the top of the
+// tree. Set isDir to `true` if the path is a folder.
+//
+// git never looks inside an ignored folder, so a path inside one is
+// always ignored, even if a later pattern would re-include it.
+func (m *Matcher) Ignored(path string, isDir bool) bool {
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' && m.ignored(path[:i], true) {
+			return true
+		}
+	}
+
+	return m.ignored(path, isDir)
+}
+
+// ignored applies the patterns to the given path, without checking
+// its parent folders
+func (m *Matcher) ignored(path string, isDir bool) bool {
+	// the last pattern that matches decides
+	for i := len(m.patterns) - 1; i >= 0; i-- {
+		if m.patterns[i].Match(path, isDir) {
+			return !m.patterns[i].negate
+		}
+	}
+
+	return false
+}
+
+// WalkFS walks the tree in fsys, calling fn for every file and folder
+// that git would not ignore. It works like `fs.WalkDir()`, starting at
+// the top of fsys.
+//
+// WalkFS reads every .gitignore file that it finds, and adds its
+// patterns to the Matcher before it looks inside that folder. It never
+// walks into the .git folder.
+func (m *Matcher) WalkFS(fsys fs.FS, fn fs.WalkDirFunc) error {
+	return fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fn(name, d, err)
+		}
+
+		if name != "." {
+			if d.IsDir() && d.Name() == ".git" {
+				return fs.SkipDir
+			}
+
+			if m.Ignored(name, d.IsDir()) {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+		}
+
+		if d.IsDir() {
+			err = m.addFromFS(fsys, name)
+			if err != nil {
+				return fn(name, d, err)
+			}
+		}
+
+		return fn(name, d, nil)
+	})
+}
+
+// addFromFS adds the patterns from the given folder's .gitignore file,
+// if it has one
+func (m *Matcher) addFromFS(fsys fs.FS, dir string) error {
+	content, err := fs.ReadFile(fsys, path.Join(dir, ".gitignore"))
+	if err != nil {
+		// it's normal for a folder not to have a .gitignore file
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	if dir == "." {
+		dir = ""
+	}
+	m.Add(dir, content)
+	return nil
+}
+
+// WalkFS walks the tree in fsys, calling fn for every file and folder
+// that git would not ignore. See `Matcher.WalkFS()` for details.
+func WalkFS(fsys fs.FS, fn fs.WalkDirFunc) error {
+	return NewMatcher().WalkFS(fsys, fn)
+}
+
+// LoadFS walks the tree in fsys, and returns a Matcher that holds the
+// patterns from every .gitignore file that git would read.
+func LoadFS(fsys fs.FS) (*Matcher, error) {
+	retval := NewMatcher()
+	err := retval.WalkFS(fsys, func(string, fs.DirEntry, error) error {
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
@@ -0,0 +1,353 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package gitignore
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type matchTestDataStruct struct {
+	pattern         string
+	path            string
+	isDir           bool
+	expectedSuccess bool
+}
+
+func TestParsePatternSkipsBlankLinesAndComments(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []string{
+		"",
+		"   ",
+		"# a comment",
+		"#",
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := ParsePattern("", testData)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, actualResult, testData)
+	}
+}
+
+func TestParsePatternTrimsUnescapedTrailingSpaces(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := map[string]string{
+		"foo   ":    "foo",
+		"foo\\ ":    "foo\\ ",
+		"foo\\  ":   "foo\\ ",
+		"foo\\\\  ": "foo\\\\",
+		"foo\r":     "foo",
+		"foo\t":     "foo\t",
+	}
+
+	for input, expectedResult := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := ParsePattern("", input)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, expectedResult, actualResult.Text, input)
+	}
+}
+
+func TestParsePatternDetectsNegationAndFolders(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	plain := ParsePattern("", "foo")
+	negated := ParsePattern("", "!foo")
+	escaped := ParsePattern("", "\\!foo")
+	dirOnly := ParsePattern("", "foo/")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.False(t, plain.IsNegated())
+	assert.False(t, plain.IsDirOnly())
+	assert.True(t, negated.IsNegated())
+	assert.False(t, escaped.IsNegated())
+	assert.True(t, dirOnly.IsDirOnly())
+}
+
+func TestPatternMatch(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []matchTestDataStruct{
+		// no slash - matches at any depth
+		{pattern: "*.log", path: "a.log", expectedSuccess: true},
+		{pattern: "*.log", path: "x/y/a.log", expectedSuccess: true},
+		{pattern: "*.log", path: "a.log/x", expectedSuccess: false},
+		// '*' and '?' never match a slash
+		{pattern: "a/*", path: "a/b", expectedSuccess: true},
+		{pattern: "a/*", path: "a/b/c", expectedSuccess: false},
+		{pattern: "a?b", path: "a/b", expectedSuccess: false},
+		// slashes anchor the pattern
+		{pattern: "/foo", path: "foo", expectedSuccess: true},
+		{pattern: "/foo", path: "x/foo", expectedSuccess: false},
+		{pattern: "a/b", path: "a/b", expectedSuccess: true},
+		{pattern: "a/b", path: "x/a/b", expectedSuccess: false},
+		// trailing slash matches folders only
+		{pattern: "build/", path: "build", isDir: true, expectedSuccess: true},
+		{pattern: "build/", path: "build", isDir: false, expectedSuccess: false},
+		{pattern: "build/", path: "x/build", isDir: true, expectedSuccess: true},
+		// leading '**/'
+		{pattern: "**/foo", path: "foo", expectedSuccess: true},
+		{pattern: "**/foo", path: "a/b/foo", expectedSuccess: true},
+		{pattern: "**/foo/bar", path: "a/foo/bar", expectedSuccess: true},
+		// trailing '/**'
+		{pattern: "abc/**", path: "abc/x/y", expectedSuccess: true},
+		{pattern: "abc/**", path: "abc", isDir: true, expectedSuccess: false},
+		// '/**/' in the middle
+		{pattern: "a/**/b", path: "a/b", expectedSuccess: true},
+		{pattern: "a/**/b", path: "a/x/y/b", expectedSuccess: true},
+		{pattern: "a/**/b", path: "x/a/b", expectedSuccess: false},
+		// any other '**' is a normal '*'
+		{pattern: "a/x**y", path: "a/xzzy", expectedSuccess: true},
+		{pattern: "a/x**y", path: "a/xz/zy", expectedSuccess: false},
+		// bracket expressions
+		{pattern: "[!a]bc", path: "xbc", expectedSuccess: true},
+		{pattern: "[!a]bc", path: "abc", expectedSuccess: false},
+		{pattern: "[]-]x", path: "]x", expectedSuccess: true},
+		// escapes
+		{pattern: "\\#lit", path: "#lit", expectedSuccess: true},
+		{pattern: "\\!bang", path: "!bang", expectedSuccess: true},
+		{pattern: "trail\\ ", path: "trail ", expectedSuccess: true},
+		// patterns that don't compile never match
+		{pattern: "abc[", path: "abc[", expectedSuccess: false},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		p := ParsePattern("", testData.pattern)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualSuccess := p.Match(testData.path, testData.isDir)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedSuccess, actualSuccess, testData)
+	}
+}
+
+func TestPatternMatchOnlyAppliesBeneathItsBase(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []matchTestDataStruct{
+		{pattern: "/foo", path: "sub/foo", expectedSuccess: true},
+		{pattern: "/foo", path: "foo", expectedSuccess: false},
+		{pattern: "/foo", path: "sub/x/foo", expectedSuccess: false},
+		{pattern: "foo", path: "sub/x/foo", expectedSuccess: true},
+		{pattern: "foo", path: "other/foo", expectedSuccess: false},
+		{pattern: "foo", path: "subfoo", expectedSuccess: false},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		p := ParsePattern("sub", testData.pattern)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualSuccess := p.Match(testData.path, testData.isDir)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedSuccess, actualSuccess, testData)
+	}
+}
+
+func TestParseRecordsLineNumbers(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	content := []byte("\xef\xbb\xbf# comment\n*.o\n\n!keep.o\n")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Parse("", content)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Len(t, actualResult, 2)
+	assert.Equal(t, "*.o", actualResult[0].Text)
+	assert.Equal(t, 2, actualResult[0].Line)
+	assert.Equal(t, "!keep.o", actualResult[1].Text)
+	assert.Equal(t, 4, actualResult[1].Line)
+}
+
+func TestMatcherIgnored(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	m := NewMatcher()
+	m.Add("", []byte("*.log\n!keep.log\n/build/*\n!/build/keep.txt\nex/\n!ex/keep.txt\n"))
+
+	testDataSet := []matchTestDataStruct{
+		{path: "a.log", expectedSuccess: true},
+		{path: "keep.log", expectedSuccess: false},
+		{path: "build/out.o", expectedSuccess: true},
+		{path: "build/keep.txt", expectedSuccess: false},
+		{path: "ex", isDir: true, expectedSuccess: true},
+		// cannot re-include a file if its folder is excluded
+		{path: "ex/keep.txt", expectedSuccess: true},
+		{path: "src/main.go", expectedSuccess: false},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualSuccess := m.Ignored(testData.path, testData.isDir)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedSuccess, actualSuccess, testData)
+	}
+}
+
+func TestWalkFSStacksNestedGitignoreFiles(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fsys := fstest.MapFS{
+		".gitignore":           {Data: []byte("*.c\nbuild/\n")},
+		"top.c":                {},
+		"README.md":            {},
+		"build/out.o":          {},
+		"build/.gitignore":     {Data: []byte("!*.c\n")},
+		"src/.gitignore":       {Data: []byte("!*.c\n/gen/\n")},
+		"src/main.c":           {},
+		"src/gen/table.go":     {},
+		"src/lib/gen/table.go": {},
+		".git/config":          {},
+	}
+	expectedResult := []string{
+		".",
+		".gitignore",
+		"README.md",
+		"src",
+		"src/.gitignore",
+		"src/lib",
+		"src/lib/gen",
+		"src/lib/gen/table.go",
+		"src/main.c",
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var actualResult []string
+	err := WalkFS(fsys, func(name string, d fs.DirEntry, err error) error {
+		actualResult = append(actualResult, name)
+		return err
+	})
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestLoadFSReturnsPatternsFromEveryGitignoreFile(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	fsys := fstest.MapFS{
+		".gitignore":       {Data: []byte("*.c\nbuild/\n")},
+		"build/.gitignore": {Data: []byte("!*.c\n")},
+		"src/.gitignore":   {Data: []byte("!*.c\n")},
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	m, err := LoadFS(fsys)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Len(t, m.Patterns(), 3)
+	assert.Equal(t, "src", m.Patterns()[2].Base)
+	assert.True(t, m.Ignored("top.c", false))
+	assert.False(t, m.Ignored("src/main.c", false))
+	assert.True(t, m.Ignored("build/main.c", false))
+}
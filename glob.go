@@ -46,26 +46,75 @@ type Glob struct {
 	pattern       string
 	patternParts  []parsedPattern
 	compiledGlobs map[int]*compiledGlob
+	parser        func(string) []parsedPattern
+	limits        globLimits
+
+	// syntax names the syntax that parser understands
+	syntax string
+
+	// limitErr is set if the pattern breaks any of the limits
+	limitErr error
 }
 
+// the syntaxes that a Glob's pattern can be written in
+const (
+	shellSyntax        = "shell"
+	editorConfigSyntax = "editorconfig"
+)
+
 // NewGlob turns your pattern into a reusable Glob
 func NewGlob(pattern string, options ...func(*Glob)) *Glob {
 	// create the Glob we're going to send back
 	retval := Glob{
 		pattern:       pattern,
-		patternParts:  parsePattern(pattern),
 		compiledGlobs: make(map[int]*compiledGlob, 5),
+		parser:        parsePattern,
+		syntax:        shellSyntax,
 	}
 
 	// apply any options we've been given
+	//
+	// we do this before parsing the pattern, because options can
+	// change which syntax the pattern uses
 	for _, option := range options {
 		option(&retval)
 	}
 
+	// a pattern that is too long might be too expensive to parse
+	if retval.limits.patternLength > 0 && len(retval.pattern) > retval.limits.patternLength {
+		retval.limitErr = &LimitError{
+			Pattern: retval.pattern,
+			Limit:   LimitPatternLength,
+			Max:     retval.limits.patternLength,
+			Actual:  len(retval.pattern),
+		}
+		return &retval
+	}
+
+	retval.patternParts = retval.parser(retval.pattern)
+	retval.limitErr = retval.checkLimits()
+
 	// all done
 	return &retval
 }
 
+// Compile turns your pattern into a reusable Glob, and checks it
+// straight away.
+//
+// Unlike NewGlob(), it returns an error as soon as it finds a problem:
+// a *LimitError if the pattern breaks one of the limits in your
+// options, or a *SyntaxError if the pattern does not compile. Use it
+// for patterns that come from people you don't trust.
+func Compile(pattern string, options ...func(*Glob)) (*Glob, error) {
+	retval := NewGlob(pattern, options...)
+	err := retval.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return retval, nil
+}
+
 // Pattern returns a copy of the original glob pattern that was compiled
 // into the given Glob
 func (g Glob) Pattern() string {
@@ -75,6 +124,10 @@ func (g Glob) Pattern() string {
 // compile creates a new regex from the previously parsed pattern, that will
 // satisfy the given flags.
 func (g *Glob) compile(flags int) (*compiledGlob, error) {
+	if g.limitErr != nil {
+		return nil, g.limitErr
+	}
+
 	retval := compiledGlob{}
 	rawRegex := buildRegex(g.patternParts, flags)
 
@@ -89,6 +142,19 @@ func (g *Glob) compile(flags int) (*compiledGlob, error) {
 		return nil, err
 	}
 
+	// an input that is shorter than the shortest possible match can
+	// never match, so there's no need to run the regex at all
+	minInputBytes := g.minInputBytes()
+	if minInputBytes > 0 {
+		matcher := retval.matcher
+		retval.matcher = func(input string) (int, bool, error) {
+			if len(input) < minInputBytes {
+				return 0, false, nil
+			}
+			return matcher(input)
+		}
+	}
+
 	// all done
 	return &retval, nil
 
@@ -181,117 +181,3 @@ func TestGlobGetCompiledGlobForFlagsReturnsErrorWhenRegexInvalid(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, actualResult)
 }
-
-func TestGlobMatchReturnsErrorWhenRegexInvalid(t *testing.T) {
-	t.Parallel()
-
-	// ----------------------------------------------------------------
-	// setup your test
-
-	// this pattern is invalid because of the mismatched '['
-	pattern := "12345["
-	g := NewGlob(pattern)
-
-	// ----------------------------------------------------------------
-	// perform the change
-
-	success, err := g.Match("")
-
-	// ----------------------------------------------------------------
-	// test the results
-
-	assert.Error(t, err)
-	assert.False(t, success)
-}
-
-func TestGlobMatchShortestPrefixReturnsErrorWhenRegexInvalid(t *testing.T) {
-	t.Parallel()
-
-	// ----------------------------------------------------------------
-	// setup your test
-
-	// this pattern is invalid because of the mismatched '['
-	pattern := "12345["
-	g := NewGlob(pattern)
-
-	// ----------------------------------------------------------------
-	// perform the change
-
-	pos, success, err := g.MatchShortestPrefix("")
-
-	// ----------------------------------------------------------------
-	// test the results
-
-	assert.Error(t, err)
-	assert.Equal(t, 0, pos)
-	assert.False(t, success)
-}
-
-func TestGlobMatchLongestPrefixReturnsErrorWhenRegexInvalid(t *testing.T) {
-	t.Parallel()
-
-	// ----------------------------------------------------------------
-	// setup your test
-
-	// this pattern is invalid because of the mismatched '['
-	pattern := "12345["
-	g := NewGlob(pattern)
-
-	// ----------------------------------------------------------------
-	// perform the change
-
-	pos, success, err := g.MatchLongestPrefix("")
-
-	// ----------------------------------------------------------------
-	// test the results
-
-	assert.Error(t, err)
-	assert.Equal(t, 0, pos)
-	assert.False(t, success)
-}
-
-func TestGlobMatchShortestSuffixReturnsErrorWhenRegexInvalid(t *testing.T) {
-	t.Parallel()
-
-	// ----------------------------------------------------------------
-	// setup your test
-
-	// this pattern is invalid because of the mismatched '['
-	pattern := "12345["
-	g := NewGlob(pattern)
-
-	// ----------------------------------------------------------------
-	// perform the change
-
-	pos, success, err := g.MatchShortestSuffix("")
-
-	// ----------------------------------------------------------------
-	// test the results
-
-	assert.Error(t, err)
-	assert.Equal(t, 0, pos)
-	assert.False(t, success)
-}
-
-func TestGlobMatchLongestSuffixReturnsErrorWhenRegexInvalid(t *testing.T) {
-	t.Parallel()
-
-	// ----------------------------------------------------------------
-	// setup your test
-
-	// this pattern is invalid because of the mismatched '['
-	pattern := "12345["
-	g := NewGlob(pattern)
-
-	// ----------------------------------------------------------------
-	// perform the change
-
-	pos, success, err := g.MatchLongestSuffix("")
-
-	// ----------------------------------------------------------------
-	// test the results
-
-	assert.Error(t, err)
-	assert.Equal(t, 0, pos)
-	assert.False(t, success)
-}
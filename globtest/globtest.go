@@ -0,0 +1,176 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Package globtest holds a conformance corpus for glob implementations.
+//
+// The corpus is a list of patterns, inputs and expected results, kept
+// in corpus.json alongside this package. Go implementations can call
+// Run() from their own tests. Implementations in other languages can
+// read corpus.json directly.
+package globtest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// Matcher is the set of match methods that the corpus checks. The
+// go_glob package's *Glob meets this interface.
+type Matcher interface {
+	Match(input string) (bool, error)
+	MatchShortestPrefix(input string) (int, bool, error)
+	MatchLongestPrefix(input string) (int, bool, error)
+	MatchShortestSuffix(input string) (int, bool, error)
+	MatchLongestSuffix(input string) (int, bool, error)
+}
+
+// Case is a single entry in the corpus.
+type Case struct {
+	// Group is the name of the set of cases that this one belongs to
+	Group string `json:"group"`
+
+	// Description says what the case is checking, if that isn't obvious
+	Description string `json:"description,omitempty"`
+
+	// Pattern is the glob pattern to match with
+	Pattern string `json:"pattern"`
+
+	// Input is the string to match against
+	Input string `json:"input"`
+
+	// Method is the name of the Matcher method to call, such as
+	// "MatchLongestPrefix"
+	Method string `json:"method"`
+
+	// Success is `true` if Method should report a match
+	Success bool `json:"success"`
+
+	// Offset is the position that Method should return. For prefix
+	// methods, it is the end of the prefix. For suffix methods, it is
+	// the start of the suffix. For Match(), it is the length of Input
+	// on success. It is always zero when there is no match.
+	Offset int `json:"offset"`
+
+	// Error is `true` if Method should return an error
+	Error bool `json:"error,omitempty"`
+}
+
+//go:embed corpus.json
+var corpusJSON []byte
+
+// Corpus returns every case in the corpus, in the order that they
+// appear in corpus.json.
+func Corpus() []Case {
+	var retval []Case
+
+	// corpus.json is part of this package, and our own tests make
+	// sure that it is valid
+	err := json.Unmarshal(corpusJSON, &retval)
+	if err != nil {
+		panic(fmt.Sprintf("globtest: corpus.json is invalid: %s", err))
+	}
+
+	return retval
+}
+
+// Check runs a single case against the given Matcher. It returns an
+// error that describes what went wrong, or nil if the Matcher gave
+// the expected result.
+func Check(m Matcher, c Case) error {
+	var offset int
+	var success bool
+	var err error
+
+	switch c.Method {
+	case "Match":
+		success, err = m.Match(c.Input)
+		if success {
+			offset = len(c.Input)
+		}
+	case "MatchShortestPrefix":
+		offset, success, err = m.MatchShortestPrefix(c.Input)
+	case "MatchLongestPrefix":
+		offset, success, err = m.MatchLongestPrefix(c.Input)
+	case "MatchShortestSuffix":
+		offset, success, err = m.MatchShortestSuffix(c.Input)
+	case "MatchLongestSuffix":
+		offset, success, err = m.MatchLongestSuffix(c.Input)
+	default:
+		return fmt.Errorf("%s: unknown method %q", c, c.Method)
+	}
+
+	if c.Error {
+		if err == nil {
+			return fmt.Errorf("%s: expected an error, got none", c)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%s: unexpected error: %s", c, err)
+	}
+	if success != c.Success || offset != c.Offset {
+		return fmt.Errorf(
+			"%s: expected success %t offset %d, got success %t offset %d",
+			c,
+			c.Success,
+			c.Offset,
+			success,
+			offset,
+		)
+	}
+
+	return nil
+}
+
+// Run checks every case in the corpus, using newMatcher to create a
+// Matcher for each case's pattern. It reports each case that fails
+// through t.
+func Run(t *testing.T, newMatcher func(pattern string) Matcher) {
+	t.Helper()
+
+	for _, c := range Corpus() {
+		err := Check(newMatcher(c.Pattern), c)
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// String returns a short description of the case, for use in error
+// messages.
+func (c Case) String() string {
+	return fmt.Sprintf("%s: %s(%q) with pattern %q", c.Group, c.Method, c.Input, c.Pattern)
+}
@@ -0,0 +1,163 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package globtest_test
+
+import (
+	"testing"
+
+	glob "github.com/ganbarodigital/go_glob"
+	"github.com/ganbarodigital/go_glob/globtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// alwaysMatches is a broken Matcher, that claims every input matches
+type alwaysMatches struct{}
+
+func (m alwaysMatches) Match(input string) (bool, error) {
+	return true, nil
+}
+
+func (m alwaysMatches) MatchShortestPrefix(input string) (int, bool, error) {
+	return len(input), true, nil
+}
+
+func (m alwaysMatches) MatchLongestPrefix(input string) (int, bool, error) {
+	return len(input), true, nil
+}
+
+func (m alwaysMatches) MatchShortestSuffix(input string) (int, bool, error) {
+	return 0, true, nil
+}
+
+func (m alwaysMatches) MatchLongestSuffix(input string) (int, bool, error) {
+	return 0, true, nil
+}
+
+func TestCorpusLoadsEveryCase(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	validMethods := map[string]bool{
+		"Match":               true,
+		"MatchShortestPrefix": true,
+		"MatchLongestPrefix":  true,
+		"MatchShortestSuffix": true,
+		"MatchLongestSuffix":  true,
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := globtest.Corpus()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.NotEmpty(t, actualResult)
+	for _, c := range actualResult {
+		assert.NotEmpty(t, c.Group, c)
+		assert.True(t, validMethods[c.Method], c)
+		assert.True(t, c.Offset >= 0 && c.Offset <= len(c.Input), c)
+	}
+}
+
+func TestCheckAcceptsCorrectResults(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range globtest.Corpus() {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := glob.NewGlob(c.Pattern)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		err := globtest.Check(g, c)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, c)
+	}
+}
+
+func TestCheckRejectsWrongResults(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []globtest.Case{
+		{
+			Group:   "test",
+			Pattern: "*.go",
+			Input:   "README.md",
+			Method:  "Match",
+		},
+		{
+			Group:   "test",
+			Pattern: "*/",
+			Input:   "path/to/folder",
+			Method:  "MatchShortestPrefix",
+			Success: true,
+			Offset:  5,
+		},
+		{
+			Group:   "test",
+			Pattern: "12345[",
+			Method:  "MatchLongestSuffix",
+			Error:   true,
+		},
+		{
+			Group:  "test",
+			Method: "MatchEverything",
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		err := globtest.Check(alwaysMatches{}, testData)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Error(t, err, testData)
+	}
+}
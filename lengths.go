@@ -0,0 +1,253 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"regexp/syntax"
+	"unicode/utf8"
+)
+
+// lengthRange holds the shortest and longest strings that a regex can
+// match, in both runes and bytes
+type lengthRange struct {
+	minRunes int
+	maxRunes int
+	minBytes int
+	maxBytes int
+
+	// bounded is `false` if there is no longest string
+	bounded bool
+}
+
+// MinLength returns the length, in runes, of the shortest string that
+// the Glob can match.
+//
+// It returns zero if the pattern does not compile.
+func (g *Glob) MinLength() int {
+	lengths, _ := g.lengths()
+	return lengths.minRunes
+}
+
+// MaxLength returns the length, in runes, of the longest string that
+// the Glob can match. It also returns `false` if there is no limit to
+// how long a matching string can be, e.g. because the pattern contains
+// a `*`.
+//
+// `???-????` has a MinLength and MaxLength of 8.
+//
+// It returns zero and `false` if the pattern does not compile.
+func (g *Glob) MaxLength() (int, bool) {
+	lengths, ok := g.lengths()
+	if !ok || !lengths.bounded {
+		return 0, false
+	}
+
+	return lengths.maxRunes, true
+}
+
+// MinByteLength returns the length, in bytes, of the shortest string
+// that the Glob can match.
+//
+// It returns zero if the pattern does not compile.
+func (g *Glob) MinByteLength() int {
+	lengths, _ := g.lengths()
+	return lengths.minBytes
+}
+
+// MaxByteLength returns the length, in bytes, of the longest string
+// that the Glob can match. It also returns `false` if there is no
+// limit to how long a matching string can be.
+//
+// It returns zero and `false` if the pattern does not compile.
+func (g *Glob) MaxByteLength() (int, bool) {
+	lengths, ok := g.lengths()
+	if !ok || !lengths.bounded {
+		return 0, false
+	}
+
+	return lengths.maxBytes, true
+}
+
+// minInputBytes returns the length, in bytes, below which the Match
+// methods turn down an input without running the regex. It is zero if
+// every input goes to the regex.
+func (g *Glob) minInputBytes() int {
+	lengths, _ := g.lengths()
+	return lengths.minBytes
+}
+
+// lengths works out how long the strings that the Glob matches can be.
+// It returns `false` if the pattern does not compile.
+//
+// we work this out from the regex that Match() uses, so that we agree
+// with the regex compiler on what each part of the pattern can match
+func (g *Glob) lengths() (lengthRange, bool) {
+	if g.limitErr != nil {
+		return lengthRange{}, false
+	}
+
+	return regexLengths(buildRegex(g.patternParts, GlobMatchWholeString))
+}
+
+// regexLengths works out how long the strings that the given regex
+// matches can be. It returns `false` if the regex does not compile.
+func regexLengths(rawRegex string) (lengthRange, bool) {
+	re, err := syntax.Parse(rawRegex, syntax.Perl)
+	if err != nil {
+		return lengthRange{}, false
+	}
+
+	return syntaxLengths(re), true
+}
+
+// syntaxLengths works out how long the strings that a parsed regex
+// matches can be
+func syntaxLengths(re *syntax.Regexp) lengthRange {
+	switch re.Op {
+	case syntax.OpLiteral:
+		retval := lengthRange{bounded: true}
+		for _, r := range re.Rune {
+			minBytes, maxBytes := runeByteLengths(r, r)
+			retval.minRunes++
+			retval.maxRunes++
+			retval.minBytes += minBytes
+			retval.maxBytes += maxBytes
+		}
+		return retval
+	case syntax.OpCharClass:
+		retval := lengthRange{minRunes: 1, maxRunes: 1, bounded: true}
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			minBytes, maxBytes := runeByteLengths(re.Rune[i], re.Rune[i+1])
+			if i == 0 || minBytes < retval.minBytes {
+				retval.minBytes = minBytes
+			}
+			retval.maxBytes = maxInt(retval.maxBytes, maxBytes)
+		}
+		return retval
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return lengthRange{minRunes: 1, maxRunes: 1, minBytes: 1, maxBytes: utf8.UTFMax, bounded: true}
+	case syntax.OpCapture:
+		return syntaxLengths(re.Sub[0])
+	case syntax.OpStar:
+		return repeatLengths(syntaxLengths(re.Sub[0]), 0, -1)
+	case syntax.OpPlus:
+		return repeatLengths(syntaxLengths(re.Sub[0]), 1, -1)
+	case syntax.OpQuest:
+		return repeatLengths(syntaxLengths(re.Sub[0]), 0, 1)
+	case syntax.OpRepeat:
+		return repeatLengths(syntaxLengths(re.Sub[0]), re.Min, re.Max)
+	case syntax.OpConcat:
+		retval := lengthRange{bounded: true}
+		for _, sub := range re.Sub {
+			subLengths := syntaxLengths(sub)
+			retval.minRunes = saturatingAdd(retval.minRunes, subLengths.minRunes)
+			retval.maxRunes = saturatingAdd(retval.maxRunes, subLengths.maxRunes)
+			retval.minBytes = saturatingAdd(retval.minBytes, subLengths.minBytes)
+			retval.maxBytes = saturatingAdd(retval.maxBytes, subLengths.maxBytes)
+			retval.bounded = retval.bounded && subLengths.bounded
+		}
+		return retval
+	case syntax.OpAlternate:
+		var retval lengthRange
+		for i, sub := range re.Sub {
+			subLengths := syntaxLengths(sub)
+			if i == 0 {
+				retval = subLengths
+				continue
+			}
+			if subLengths.minRunes < retval.minRunes {
+				retval.minRunes = subLengths.minRunes
+			}
+			if subLengths.minBytes < retval.minBytes {
+				retval.minBytes = subLengths.minBytes
+			}
+			retval.maxRunes = maxInt(retval.maxRunes, subLengths.maxRunes)
+			retval.maxBytes = maxInt(retval.maxBytes, subLengths.maxBytes)
+			retval.bounded = retval.bounded && subLengths.bounded
+		}
+		return retval
+	}
+
+	// everything else (anchors, word boundaries, empty matches) matches
+	// without using up any of the input
+	return lengthRange{bounded: true}
+}
+
+// repeatLengths works out how long a repeated sub-expression can be.
+// A max of -1 means that there is no limit on the repeats.
+func repeatLengths(sub lengthRange, min, max int) lengthRange {
+	retval := lengthRange{
+		minRunes: saturatingMultiply(sub.minRunes, min),
+		minBytes: saturatingMultiply(sub.minBytes, min),
+		bounded:  true,
+	}
+
+	switch {
+	case sub.bounded && sub.maxBytes == 0:
+		// repeating an empty match is still an empty match
+	case max < 0 || !sub.bounded:
+		retval.bounded = false
+	default:
+		retval.maxRunes = saturatingMultiply(sub.maxRunes, max)
+		retval.maxBytes = saturatingMultiply(sub.maxBytes, max)
+	}
+
+	return retval
+}
+
+// runeByteLengths returns the fewest and most bytes that the UTF-8
+// encoding of a rune between lo and hi (inclusive) can use
+func runeByteLengths(lo, hi rune) (int, int) {
+	minBytes := utf8.RuneLen(lo)
+	maxBytes := utf8.RuneLen(hi)
+
+	// surrogates can't be encoded; the nearest runes that can be are
+	// 3 bytes long
+	if minBytes < 0 {
+		minBytes = 3
+	}
+	if maxBytes < 0 {
+		maxBytes = 3
+	}
+
+	// the regex engine reads each invalid byte in the input as a
+	// utf8.RuneError, so anything that matches utf8.RuneError can also
+	// match a single byte
+	if lo <= utf8.RuneError && hi >= utf8.RuneError {
+		minBytes = 1
+	}
+
+	return minBytes, maxBytes
+}
@@ -0,0 +1,207 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobMinAndMaxLength(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern          string
+		options          []func(*Glob)
+		expectedMinRunes int
+		expectedMaxRunes int
+		expectedMinBytes int
+		expectedMaxBytes int
+		expectedBounded  bool
+	}{
+		{
+			pattern:          "???-????",
+			expectedMinRunes: 8,
+			expectedMaxRunes: 8,
+			expectedMinBytes: 8,
+			expectedMaxBytes: 29,
+			expectedBounded:  true,
+		},
+		{
+			pattern:          "[0-9][0-9]",
+			expectedMinRunes: 2,
+			expectedMaxRunes: 2,
+			expectedMinBytes: 2,
+			expectedMaxBytes: 2,
+			expectedBounded:  true,
+		},
+		{
+			pattern:          "café",
+			expectedMinRunes: 4,
+			expectedMaxRunes: 4,
+			expectedMinBytes: 5,
+			expectedMaxBytes: 5,
+			expectedBounded:  true,
+		},
+		{
+			pattern:          "[éü]",
+			expectedMinRunes: 1,
+			expectedMaxRunes: 1,
+			expectedMinBytes: 2,
+			expectedMaxBytes: 2,
+			expectedBounded:  true,
+		},
+		{
+			pattern:          "src/*.go",
+			expectedMinRunes: 7,
+			expectedMinBytes: 7,
+		},
+		{
+			pattern:         "",
+			expectedBounded: true,
+		},
+		{
+			pattern:          "{a,bcd}.txt",
+			options:          []func(*Glob){EditorConfigSyntax},
+			expectedMinRunes: 5,
+			expectedMaxRunes: 7,
+			expectedMinBytes: 5,
+			expectedMaxBytes: 7,
+			expectedBounded:  true,
+		},
+		{
+			// numeric ranges accept any number of leading zeros
+			pattern:          "v{-5..120}",
+			options:          []func(*Glob){EditorConfigSyntax},
+			expectedMinRunes: 2,
+			expectedMinBytes: 2,
+		},
+		{
+			pattern:          "a/**/b",
+			options:          []func(*Glob){EditorConfigSyntax},
+			expectedMinRunes: 3,
+			expectedMinBytes: 3,
+		},
+		{
+			// this pattern does not compile
+			pattern: "12345[",
+		},
+		{
+			// this pattern breaks a limit
+			pattern: "abc",
+			options: []func(*Glob){MaxPatternLength(2)},
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern, testData.options...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualMinRunes := g.MinLength()
+		actualMaxRunes, actualRunesBounded := g.MaxLength()
+		actualMinBytes := g.MinByteLength()
+		actualMaxBytes, actualBytesBounded := g.MaxByteLength()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedMinRunes, actualMinRunes, testData.pattern)
+		assert.Equal(t, testData.expectedMaxRunes, actualMaxRunes, testData.pattern)
+		assert.Equal(t, testData.expectedBounded, actualRunesBounded, testData.pattern)
+		assert.Equal(t, testData.expectedMinBytes, actualMinBytes, testData.pattern)
+		assert.Equal(t, testData.expectedMaxBytes, actualMaxBytes, testData.pattern)
+		assert.Equal(t, testData.expectedBounded, actualBytesBounded, testData.pattern)
+	}
+}
+
+func TestGlobMinByteLengthAllowsForInvalidUTF8(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// the regex engine reads an invalid byte as utf8.RuneError, which
+	// '?' matches
+	g := NewGlob("?")
+	input := "\xff"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualMinBytes := g.MinByteLength()
+	success, err := g.Match(input)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, 1, actualMinBytes)
+	assert.Nil(t, err)
+	assert.True(t, success)
+}
+
+func TestGlobMatchMethodsRejectInputsShorterThanMinByteLength(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	g := NewGlob("*abc*")
+	input := "ab"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	wholeSuccess, wholeErr := g.Match(input)
+	prefixOffset, prefixSuccess, prefixErr := g.MatchShortestPrefix(input)
+	suffixOffset, suffixSuccess, suffixErr := g.MatchLongestSuffix(input)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, wholeErr)
+	assert.False(t, wholeSuccess)
+	assert.Nil(t, prefixErr)
+	assert.False(t, prefixSuccess)
+	assert.Equal(t, 0, prefixOffset)
+	assert.Nil(t, suffixErr)
+	assert.False(t, suffixSuccess)
+	assert.Equal(t, 0, suffixOffset)
+}
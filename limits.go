@@ -0,0 +1,311 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"fmt"
+	"regexp/syntax"
+)
+
+// Limit identifies one of the complexity limits that you can put on
+// a Glob.
+type Limit int
+
+const (
+	// LimitPatternLength is the maximum length of the pattern, in bytes
+	LimitPatternLength Limit = iota
+
+	// LimitWildcards is the maximum number of '*', '?' and '**'
+	// wildcards in the pattern
+	LimitWildcards
+
+	// LimitNestingDepth is the maximum depth of nested braces in the
+	// pattern
+	LimitNestingDepth
+
+	// LimitFanOut is the maximum number of strings that the pattern's
+	// braces would expand into
+	LimitFanOut
+
+	// LimitProgramSize is the maximum number of instructions in the
+	// compiled regex
+	LimitProgramSize
+)
+
+// LimitError is the error that the Match methods return when a Glob's
+// pattern breaks one of its complexity limits.
+type LimitError struct {
+	// Pattern is the pattern that broke the limit
+	Pattern string
+
+	// Limit says which limit was broken
+	Limit Limit
+
+	// Max is the limit that was set
+	Max int
+
+	// Actual is how far the pattern went. For LimitPatternLength, it
+	// is the pattern's length. For the other limits, it is the first
+	// value that we found over the limit.
+	Actual int
+}
+
+// largestInt is the largest value that an int can hold
+const largestInt = int(^uint(0) >> 1)
+
+// globLimits holds any complexity limits set on a Glob. Zero means
+// no limit.
+type globLimits struct {
+	patternLength int
+	wildcards     int
+	nestingDepth  int
+	fanOut        int
+	programSize   int
+}
+
+// MaxPatternLength is an option for NewGlob(). It limits the length of
+// the pattern, in bytes.
+//
+// A pattern that is too long is not parsed at all.
+func MaxPatternLength(max int) func(*Glob) {
+	return func(g *Glob) {
+		g.limits.patternLength = max
+	}
+}
+
+// MaxWildcards is an option for NewGlob(). It limits how many '*', '?'
+// and '**' wildcards the pattern can use.
+func MaxWildcards(max int) func(*Glob) {
+	return func(g *Glob) {
+		g.limits.wildcards = max
+	}
+}
+
+// MaxNestingDepth is an option for NewGlob(). It limits how deeply
+// braces can be nested inside each other. `{a,b}` has a depth of 1,
+// and `{a,{b,c}}` has a depth of 2.
+//
+// Only EditorConfigSyntax supports braces.
+func MaxNestingDepth(max int) func(*Glob) {
+	return func(g *Glob) {
+		g.limits.nestingDepth = max
+	}
+}
+
+// MaxFanOut is an option for NewGlob(). It limits how many strings the
+// pattern's braces would expand into, if they were expanded like the
+// shell does. `{a,b}{c,d}` expands into 4 strings, and `{1..10}` into
+// 10.
+//
+// Only EditorConfigSyntax supports braces.
+func MaxFanOut(max int) func(*Glob) {
+	return func(g *Glob) {
+		g.limits.fanOut = max
+	}
+}
+
+// MaxProgramSize is an option for NewGlob(). It limits how many
+// instructions the compiled regex can have.
+func MaxProgramSize(max int) func(*Glob) {
+	return func(g *Glob) {
+		g.limits.programSize = max
+	}
+}
+
+// checkLimits returns a *LimitError if the Glob breaks any of its
+// complexity limits
+//
+// it is only called once the pattern has been parsed
+func (g *Glob) checkLimits() error {
+	l := g.limits
+
+	if l.wildcards > 0 {
+		n := countWildcards(g.patternParts)
+		if n > l.wildcards {
+			return &LimitError{Pattern: g.pattern, Limit: LimitWildcards, Max: l.wildcards, Actual: n}
+		}
+	}
+
+	if l.nestingDepth > 0 {
+		n := nestingDepth(g.patternParts)
+		if n > l.nestingDepth {
+			return &LimitError{Pattern: g.pattern, Limit: LimitNestingDepth, Max: l.nestingDepth, Actual: n}
+		}
+	}
+
+	if l.fanOut > 0 {
+		n := fanOut(g.patternParts)
+		if n > l.fanOut {
+			return &LimitError{Pattern: g.pattern, Limit: LimitFanOut, Max: l.fanOut, Actual: n}
+		}
+	}
+
+	if l.programSize > 0 {
+		n, err := programSize(g.patternParts)
+		if err == nil && n > l.programSize {
+			return &LimitError{Pattern: g.pattern, Limit: LimitProgramSize, Max: l.programSize, Actual: n}
+		}
+	}
+
+	return nil
+}
+
+// countWildcards returns how many wildcards the parsed pattern uses
+func countWildcards(parts []parsedPattern) int {
+	retval := 0
+	for _, part := range parts {
+		switch part.patternType {
+		case patternTypeSingleMatch, patternTypeMultiMatch,
+			patternTypeSegmentSingleMatch, patternTypeSegmentMultiMatch,
+			patternTypeGlobStar:
+			retval++
+		case patternTypeAlternatives:
+			for _, alternative := range part.alternatives {
+				retval += countWildcards(alternative)
+			}
+		}
+	}
+
+	return retval
+}
+
+// nestingDepth returns how deeply the parsed pattern's braces are
+// nested
+func nestingDepth(parts []parsedPattern) int {
+	retval := 0
+	for _, part := range parts {
+		depth := 0
+		switch part.patternType {
+		case patternTypeNumericRange:
+			depth = 1
+		case patternTypeAlternatives:
+			for _, alternative := range part.alternatives {
+				depth = maxInt(depth, 1+nestingDepth(alternative))
+			}
+		}
+		retval = maxInt(retval, depth)
+	}
+
+	return retval
+}
+
+// fanOut returns how many strings the parsed pattern's braces would
+// expand into. It stops counting at the largest possible int.
+func fanOut(parts []parsedPattern) int {
+	retval := 1
+	for _, part := range parts {
+		n := 1
+		switch part.patternType {
+		case patternTypeNumericRange:
+			lo, hi, _ := parseNumericRange(part.pattern)
+			span := uint64(hi - lo)
+			if span >= uint64(largestInt) {
+				n = largestInt
+			} else {
+				n = int(span) + 1
+			}
+		case patternTypeAlternatives:
+			n = 0
+			for _, alternative := range part.alternatives {
+				n = saturatingAdd(n, fanOut(alternative))
+			}
+		}
+		retval = saturatingMultiply(retval, n)
+	}
+
+	return retval
+}
+
+// programSize returns how many instructions the Match() regex compiles
+// into
+func programSize(parts []parsedPattern) (int, error) {
+	re, err := syntax.Parse(buildRegex(parts, GlobMatchWholeString), syntax.Perl)
+	if err != nil {
+		return 0, err
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return 0, err
+	}
+
+	return len(prog.Inst), nil
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func saturatingAdd(a, b int) int {
+	if a > largestInt-b {
+		return largestInt
+	}
+
+	return a + b
+}
+
+func saturatingMultiply(a, b int) int {
+	if a != 0 && b > largestInt/a {
+		return largestInt
+	}
+
+	return a * b
+}
+
+// Error returns a human-readable description of the broken limit.
+//
+// It meets the error interface.
+func (e *LimitError) Error() string {
+	var what string
+	switch e.Limit {
+	case LimitPatternLength:
+		what = "is %d bytes long, more than the limit of %d"
+	case LimitWildcards:
+		what = "has %d wildcards, more than the limit of %d"
+	case LimitNestingDepth:
+		what = "nests braces %d deep, more than the limit of %d"
+	case LimitFanOut:
+		what = "expands into %d strings, more than the limit of %d"
+	case LimitProgramSize:
+		what = "compiles into %d instructions, more than the limit of %d"
+	default:
+		what = "breaks a limit: %d is more than %d"
+	}
+
+	return fmt.Sprintf("glob pattern '%s' "+what, e.Pattern, e.Actual, e.Max)
+}
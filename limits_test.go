@@ -0,0 +1,350 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobMatchReturnsLimitErrorWhenPatternBreaksALimit(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern       string
+		options       []func(*Glob)
+		expectedLimit Limit
+		expectedMax   int
+		expectedValue int
+	}{
+		{
+			pattern:       "abcdefghij",
+			options:       []func(*Glob){MaxPatternLength(5)},
+			expectedLimit: LimitPatternLength,
+			expectedMax:   5,
+			expectedValue: 10,
+		},
+		{
+			pattern:       "*a?b*c*",
+			options:       []func(*Glob){MaxWildcards(3)},
+			expectedLimit: LimitWildcards,
+			expectedMax:   3,
+			expectedValue: 4,
+		},
+		{
+			pattern:       "src/**/*.{go,m?}",
+			options:       []func(*Glob){EditorConfigSyntax, MaxWildcards(2)},
+			expectedLimit: LimitWildcards,
+			expectedMax:   2,
+			expectedValue: 3,
+		},
+		{
+			pattern:       "{a,{b,{c,d}}}",
+			options:       []func(*Glob){EditorConfigSyntax, MaxNestingDepth(2)},
+			expectedLimit: LimitNestingDepth,
+			expectedMax:   2,
+			expectedValue: 3,
+		},
+		{
+			pattern:       "{a,b}{c,d}{e,f}",
+			options:       []func(*Glob){EditorConfigSyntax, MaxFanOut(4)},
+			expectedLimit: LimitFanOut,
+			expectedMax:   4,
+			expectedValue: 8,
+		},
+		{
+			pattern:       "file{1..100}",
+			options:       []func(*Glob){EditorConfigSyntax, MaxFanOut(10)},
+			expectedLimit: LimitFanOut,
+			expectedMax:   10,
+			expectedValue: 100,
+		},
+		{
+			pattern:       "{-9223372036854775808..9223372036854775807}{a,b}",
+			options:       []func(*Glob){EditorConfigSyntax, MaxFanOut(10)},
+			expectedLimit: LimitFanOut,
+			expectedMax:   10,
+			expectedValue: largestInt,
+		},
+		{
+			pattern:       "*a*b*c*d*e*f*g*h*",
+			options:       []func(*Glob){MaxProgramSize(10)},
+			expectedLimit: LimitProgramSize,
+			expectedMax:   10,
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern, testData.options...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		success, err := g.Match("")
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.False(t, success, testData.pattern)
+
+		var limitErr *LimitError
+		if !assert.True(t, errors.As(err, &limitErr), testData.pattern) {
+			continue
+		}
+		assert.Equal(t, testData.pattern, limitErr.Pattern)
+		assert.Equal(t, testData.expectedLimit, limitErr.Limit, testData.pattern)
+		assert.Equal(t, testData.expectedMax, limitErr.Max, testData.pattern)
+		if testData.expectedValue > 0 {
+			assert.Equal(t, testData.expectedValue, limitErr.Actual, testData.pattern)
+		} else {
+			assert.Greater(t, limitErr.Actual, testData.expectedMax, testData.pattern)
+		}
+	}
+}
+
+func TestGlobMatchesWhenPatternIsInsideAllLimits(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	g := NewGlob(
+		"src/{lib,cmd/{a,b}}/*.go",
+		EditorConfigSyntax,
+		MaxPatternLength(24),
+		MaxWildcards(1),
+		MaxNestingDepth(2),
+		MaxFanOut(3),
+		MaxProgramSize(1000),
+	)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	success, err := g.Match("src/cmd/b/main.go")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.True(t, success)
+}
+
+func TestCompileReturnsLimitErrorStraightAway(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern       string
+		options       []func(*Glob)
+		expectedLimit Limit
+	}{
+		{"abcdef", []func(*Glob){MaxPatternLength(3)}, LimitPatternLength},
+		{"*/*/*", []func(*Glob){MaxWildcards(2)}, LimitWildcards},
+		{"{a,{b,{c,d}}}", []func(*Glob){EditorConfigSyntax, MaxNestingDepth(2)}, LimitNestingDepth},
+		{"{a,b}{c,d}{e,f}", []func(*Glob){EditorConfigSyntax, MaxFanOut(4)}, LimitFanOut},
+		{"*a*b*c*d", []func(*Glob){MaxProgramSize(5)}, LimitProgramSize},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		g, err := Compile(testData.pattern, testData.options...)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, g, testData.pattern)
+
+		var limitErr *LimitError
+		if assert.True(t, errors.As(err, &limitErr), testData.pattern) {
+			assert.Equal(t, testData.expectedLimit, limitErr.Limit, testData.pattern)
+			assert.Equal(t, testData.pattern, limitErr.Pattern)
+		}
+	}
+}
+
+func TestCompileReturnsSyntaxErrorStraightAway(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	g, err := Compile("ab[z-a]cd")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, g)
+
+	var syntaxErr *SyntaxError
+	if assert.True(t, errors.As(err, &syntaxErr)) {
+		assert.Equal(t, 2, syntaxErr.Offset)
+	}
+}
+
+func TestCompileReturnsGlobForValidPattern(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	g, err := Compile("src/**/*.go", EditorConfigSyntax, MaxWildcards(2))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	if assert.NotNil(t, g) {
+		success, err := g.Match("src/cmd/main.go")
+		assert.Nil(t, err)
+		assert.True(t, success)
+	}
+}
+
+func TestNewGlobDoesNotParsePatternThatIsTooLong(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	parsed := false
+	parser := func(g *Glob) {
+		g.parser = func(pattern string) []parsedPattern {
+			parsed = true
+			return parsePattern(pattern)
+		}
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	g := NewGlob("abcdef", parser, MaxPatternLength(3))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.False(t, parsed)
+	assert.Nil(t, g.patternParts)
+}
+
+func TestLimitErrorDescribesTheBrokenLimit(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		limit          Limit
+		expectedResult string
+	}{
+		{
+			limit:          LimitPatternLength,
+			expectedResult: "glob pattern '*.go' is 9 bytes long, more than the limit of 5",
+		},
+		{
+			limit:          LimitWildcards,
+			expectedResult: "glob pattern '*.go' has 9 wildcards, more than the limit of 5",
+		},
+		{
+			limit:          LimitNestingDepth,
+			expectedResult: "glob pattern '*.go' nests braces 9 deep, more than the limit of 5",
+		},
+		{
+			limit:          LimitFanOut,
+			expectedResult: "glob pattern '*.go' expands into 9 strings, more than the limit of 5",
+		},
+		{
+			limit:          LimitProgramSize,
+			expectedResult: "glob pattern '*.go' compiles into 9 instructions, more than the limit of 5",
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		err := &LimitError{Pattern: "*.go", Limit: testData.limit, Max: 5, Actual: 9}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := err.Error()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedResult, actualResult)
+	}
+}
+
+func TestLintReportsGlobThatBreaksALimitAsInvalid(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rules := []Rule{
+		{Glob: NewGlob("*.go", MaxWildcards(1)), Line: 1},
+		{Glob: NewGlob("*.*.go", MaxWildcards(1)), Line: 2},
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Lint(rules, FirstMatchWins)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	if assert.Len(t, actualResult, 1) {
+		assert.Equal(t, DiagnosticInvalid, actualResult[0].Kind)
+		assert.Equal(t, 2, actualResult[0].Rule.Line)
+
+		var limitErr *LimitError
+		assert.True(t, errors.As(actualResult[0].Err, &limitErr))
+	}
+}
@@ -0,0 +1,268 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuleOrder says which rule wins when more than one rule in a list
+// matches the same string.
+type RuleOrder int
+
+const (
+	// FirstMatchWins means that the earliest matching rule in the list
+	// decides what happens
+	FirstMatchWins RuleOrder = iota
+
+	// LastMatchWins means that the latest matching rule in the list
+	// decides what happens, as in .gitignore files
+	LastMatchWins
+)
+
+// Rule is a single entry in an ordered list of patterns.
+type Rule struct {
+	// Glob is the rule's pattern
+	Glob *Glob
+
+	// Line is where the rule appears in its file. It is only used in
+	// Diagnostics.
+	Line int
+
+	// Deny is `true` if the rule denies the strings it matches, and
+	// `false` if it allows them. It doesn't change which rules can
+	// decide the outcome, but Diagnostics show it.
+	Deny bool
+}
+
+// String returns the rule's pattern, with a leading '!' if it is a
+// deny rule.
+func (r Rule) String() string {
+	if r.Deny {
+		return "!" + r.Glob.Pattern()
+	}
+
+	return r.Glob.Pattern()
+}
+
+// DiagnosticKind says what problem the linter found with a rule.
+type DiagnosticKind int
+
+const (
+	// DiagnosticInvalid means that the rule's pattern does not compile
+	DiagnosticInvalid DiagnosticKind = iota
+
+	// DiagnosticMatchesNothing means that there is no string that the
+	// rule's pattern can match
+	DiagnosticMatchesNothing
+
+	// DiagnosticDuplicate means that the rule matches exactly the same
+	// strings as another rule, and the other rule always wins
+	DiagnosticDuplicate
+
+	// DiagnosticShadowed means that, under FirstMatchWins, earlier rules
+	// match everything that this rule matches
+	DiagnosticShadowed
+
+	// DiagnosticOverridden means that, under LastMatchWins, later rules
+	// match everything that this rule matches
+	DiagnosticOverridden
+)
+
+// Diagnostic is a single problem that the linter found.
+type Diagnostic struct {
+	// Kind says what the problem is
+	Kind DiagnosticKind
+
+	// Rule is the rule that has the problem
+	Rule Rule
+
+	// Other is the rule that causes the problem, for duplicate, shadowed
+	// and overridden rules. It is nil if no single rule is to blame.
+	Other *Rule
+
+	// Err is the compile error, for invalid rules
+	Err error
+}
+
+// Lint checks an ordered list of rules, and returns any rules that can
+// never decide the outcome.
+//
+// The diagnostics are returned in the same order as the rules. Each
+// rule gets at most one diagnostic.
+func Lint(rules []Rule, order RuleOrder) []Diagnostic {
+	var retval []Diagnostic
+
+	// build each automaton once, up front
+	nfas := make([]*nfa, len(rules))
+	hints := make([]lintHint, len(rules))
+	for i, rule := range rules {
+		nfas[i] = newNFA(rule.Glob)
+		hints[i] = newLintHint(rule.Glob)
+	}
+
+	for i, rule := range rules {
+		if nfas[i] == nil {
+			_, err := rule.Glob.Match("")
+			retval = append(retval, Diagnostic{Kind: DiagnosticInvalid, Rule: rule, Err: err})
+			continue
+		}
+		if nfas[i].matchesNothing() {
+			retval = append(retval, Diagnostic{Kind: DiagnosticMatchesNothing, Rule: rule})
+			continue
+		}
+
+		// which rules win over this one?
+		start, end := 0, i
+		kind := DiagnosticShadowed
+		if order == LastMatchWins {
+			start, end = i+1, len(rules)
+			kind = DiagnosticOverridden
+		}
+
+		// building automata is expensive, so we leave out any rule
+		// that can't match the same strings as this one
+		var winners []Rule
+		var winnerNFAs []*nfa
+		for j := start; j < end; j++ {
+			if hints[i].mightOverlap(hints[j]) {
+				winners = append(winners, rules[j])
+				winnerNFAs = append(winnerNFAs, nfas[j])
+			}
+		}
+		if len(winners) == 0 {
+			continue
+		}
+
+		diag := lintAgainst(rule, nfas[i], winners, winnerNFAs, kind)
+		if diag != nil {
+			retval = append(retval, *diag)
+		}
+	}
+
+	return retval
+}
+
+// lintAgainst checks whether the given rule is covered by the rules
+// that win over it
+func lintAgainst(rule Rule, ruleNFA *nfa, winners []Rule, winnerNFAs []*nfa, kind DiagnosticKind) *Diagnostic {
+	// is a single rule to blame?
+	var covering *Rule
+	for i, winnerNFA := range winnerNFAs {
+		if winnerNFA == nil {
+			continue
+		}
+		if _, found := findCounterexample(winnerNFA, ruleNFA); found {
+			continue
+		}
+
+		// it is a duplicate if the subsumption works both ways
+		if _, found := findCounterexample(ruleNFA, winnerNFA); !found {
+			return &Diagnostic{Kind: DiagnosticDuplicate, Rule: rule, Other: &winners[i]}
+		}
+		if covering == nil {
+			covering = &winners[i]
+		}
+	}
+	if covering != nil {
+		return &Diagnostic{Kind: kind, Rule: rule, Other: covering}
+	}
+
+	// are the winners to blame between them?
+	globs := make([]*Glob, len(winners))
+	for i, winner := range winners {
+		globs[i] = winner.Glob
+	}
+	if _, found := findCounterexample(newUnionNFA(globs), ruleNFA); !found {
+		return &Diagnostic{Kind: kind, Rule: rule}
+	}
+
+	// if we get here, the rule is fine
+	return nil
+}
+
+// lintHint holds the literal text that every string matched by a rule
+// must start and end with
+type lintHint struct {
+	prefix string
+	suffix string
+}
+
+// newLintHint works out the lintHint for the given Glob
+func newLintHint(g *Glob) lintHint {
+	prefix, _ := g.LiteralPrefix()
+	suffix, _ := g.LiteralSuffix()
+
+	return lintHint{prefix: prefix, suffix: suffix}
+}
+
+// mightOverlap returns `false` if no string can match both rules. Two
+// rules can only match the same string if one's literal prefix starts
+// the other's, and the same goes for their literal suffixes.
+func (h lintHint) mightOverlap(other lintHint) bool {
+	if !strings.HasPrefix(h.prefix, other.prefix) && !strings.HasPrefix(other.prefix, h.prefix) {
+		return false
+	}
+
+	return strings.HasSuffix(h.suffix, other.suffix) || strings.HasSuffix(other.suffix, h.suffix)
+}
+
+// String returns a human-readable description of the problem, starting
+// with the rule's line number.
+func (d Diagnostic) String() string {
+	prefix := fmt.Sprintf("line %d: '%s'", d.Rule.Line, d.Rule)
+
+	switch d.Kind {
+	case DiagnosticInvalid:
+		return fmt.Sprintf("%s is invalid: %s", prefix, d.Err)
+	case DiagnosticMatchesNothing:
+		return fmt.Sprintf("%s matches nothing", prefix)
+	case DiagnosticDuplicate:
+		return fmt.Sprintf("%s is a duplicate of line %d ('%s')", prefix, d.Other.Line, d.Other)
+	case DiagnosticShadowed:
+		if d.Other == nil {
+			return fmt.Sprintf("%s can never match; earlier rules match everything it does", prefix)
+		}
+		return fmt.Sprintf("%s can never match; line %d ('%s') matches everything it does", prefix, d.Other.Line, d.Other)
+	case DiagnosticOverridden:
+		if d.Other == nil {
+			return fmt.Sprintf("%s is always overridden by later rules", prefix)
+		}
+		return fmt.Sprintf("%s is always overridden by line %d ('%s')", prefix, d.Other.Line, d.Other)
+	}
+
+	return prefix
+}
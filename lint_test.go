@@ -0,0 +1,280 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildRules turns a list of patterns into Rules, numbered from line 1
+func buildRules(patterns ...string) []Rule {
+	retval := make([]Rule, len(patterns))
+	for i, pattern := range patterns {
+		retval[i] = Rule{Glob: NewGlob(pattern), Line: i + 1}
+	}
+
+	return retval
+}
+
+func TestLintFindsProblemsWhenFirstMatchWins(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rules := buildRules(
+		"src/*",
+		"src/*.go",
+		"*.md",
+		"docs/[a-m]*",
+		"docs/[n-z]*",
+		"docs/*",
+		"*.md",
+		"*.[m]d",
+		"[![:ascii:][:^ascii:]]",
+		"12345[",
+		"*.txt",
+	)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Lint(rules, FirstMatchWins)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	var actualSummary []string
+	for _, diag := range actualResult {
+		actualSummary = append(actualSummary, diag.String())
+	}
+	assert.Equal(
+		t,
+		[]string{
+			"line 2: 'src/*.go' can never match; line 1 ('src/*') matches everything it does",
+			"line 7: '*.md' is a duplicate of line 3 ('*.md')",
+			"line 8: '*.[m]d' is a duplicate of line 3 ('*.md')",
+			"line 9: '[![:ascii:][:^ascii:]]' matches nothing",
+			"line 10: '12345[' is invalid: bad or unsupported glob pattern '12345[': error parsing regexp: missing closing ]: `[$`",
+		},
+		actualSummary,
+	)
+}
+
+func TestLintFindsRulesCoveredByMoreThanOneRule(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rules := buildRules(
+		"docs/[a-m]*",
+		"docs/[!a-m]*",
+		"docs/?*",
+	)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Lint(rules, FirstMatchWins)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Len(t, actualResult, 1)
+	assert.Equal(t, DiagnosticShadowed, actualResult[0].Kind)
+	assert.Equal(t, 3, actualResult[0].Rule.Line)
+	assert.Nil(t, actualResult[0].Other)
+	assert.Equal(
+		t,
+		"line 3: 'docs/?*' can never match; earlier rules match everything it does",
+		actualResult[0].String(),
+	)
+}
+
+func TestLintFindsProblemsWhenLastMatchWins(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rules := buildRules(
+		"*.log",
+		"build/*",
+		"debug.log",
+		"*",
+		"*.md",
+		"README.md",
+		"*.md",
+	)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Lint(rules, LastMatchWins)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	var actualSummary []string
+	for _, diag := range actualResult {
+		actualSummary = append(actualSummary, diag.String())
+	}
+	assert.Equal(
+		t,
+		[]string{
+			"line 1: '*.log' is always overridden by line 4 ('*')",
+			"line 2: 'build/*' is always overridden by line 4 ('*')",
+			"line 3: 'debug.log' is always overridden by line 4 ('*')",
+			"line 5: '*.md' is a duplicate of line 7 ('*.md')",
+			"line 6: 'README.md' is always overridden by line 7 ('*.md')",
+		},
+		actualSummary,
+	)
+}
+
+func TestLintReturnsNothingForCleanRules(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rules := buildRules("src/*.go", "src/*", "*.md")
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Lint(rules, FirstMatchWins)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Empty(t, actualResult)
+}
+
+func TestLintDiagnosticsShowDenyRules(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	rules := []Rule{
+		{Glob: NewGlob("*.log"), Line: 1, Deny: true},
+		{Glob: NewGlob("debug.log"), Line: 2},
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Lint(rules, FirstMatchWins)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	if assert.Len(t, actualResult, 1) {
+		assert.Equal(
+			t,
+			"line 2: 'debug.log' can never match; line 1 ('!*.log') matches everything it does",
+			actualResult[0].String(),
+		)
+	}
+}
+
+func TestLintHintRulesOutRulesThatCannotOverlap(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		a              string
+		b              string
+		expectedResult bool
+	}{
+		{"src/1/*/*.go", "src/2/*/*.go", false},
+		{"src/1/*/*.go", "src/10/*/*.go", false},
+		{"src/1/*/*.go", "src/*", true},
+		{"src/*.go", "*.md", false},
+		{"src/*.go", "*.go", true},
+		{"*", "abc", true},
+		{"a*", "*b", true},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		a := newLintHint(NewGlob(testData.a))
+		b := newLintHint(NewGlob(testData.b))
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := a.mightOverlap(b)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedResult, actualResult, testData)
+		assert.Equal(t, actualResult, b.mightOverlap(a), testData)
+	}
+}
+
+func TestLintLeavesOutRulesThatCannotOverlap(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	var patterns []string
+	for i := 0; i < 300; i++ {
+		patterns = append(patterns, fmt.Sprintf("src/%d/*/*.go", i))
+	}
+	patterns = append(patterns, "src/42/*/main.go")
+	rules := buildRules(patterns...)
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := Lint(rules, FirstMatchWins)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	if assert.Len(t, actualResult, 1) {
+		assert.Equal(t, DiagnosticShadowed, actualResult[0].Kind)
+		assert.Equal(t, 43, actualResult[0].Other.Line)
+	}
+}
@@ -0,0 +1,144 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"strings"
+)
+
+// LiteralPrefix returns the literal text that every string matched by
+// the Glob must start with. It also returns `true` if the whole
+// pattern is literal text, in which case the Glob only matches the
+// returned string.
+//
+// The returned text is unescaped: the prefix of `\*.go*` is `*.go`.
+//
+// If the pattern does not compile, it returns an empty string and
+// `false`.
+func (g *Glob) LiteralPrefix() (string, bool) {
+	runs, ok := g.literalRuns()
+	if !ok {
+		return "", false
+	}
+
+	return runs[0], len(runs) == 1
+}
+
+// LiteralSuffix returns the literal text that every string matched by
+// the Glob must end with. It also returns `true` if the whole pattern
+// is literal text, in which case the Glob only matches the returned
+// string.
+//
+// The returned text is unescaped: the suffix of `*\?` is `?`.
+//
+// If the pattern does not compile, it returns an empty string and
+// `false`.
+func (g *Glob) LiteralSuffix() (string, bool) {
+	runs, ok := g.literalRuns()
+	if !ok {
+		return "", false
+	}
+
+	return runs[len(runs)-1], len(runs) == 1
+}
+
+// RequiredSubstrings returns each run of literal text in the pattern,
+// in the order that they appear. Every string matched by the Glob
+// contains all of them, in that order, without overlapping. It also
+// returns `true` if the whole pattern is literal text.
+//
+// Use them to rule out strings cheaply, before calling `Match()`.
+//
+// If the pattern does not compile, it returns nil and `false`.
+func (g *Glob) RequiredSubstrings() ([]string, bool) {
+	runs, ok := g.literalRuns()
+	if !ok {
+		return nil, false
+	}
+
+	var retval []string
+	for _, run := range runs {
+		if run != "" {
+			retval = append(retval, run)
+		}
+	}
+
+	return retval, len(runs) == 1
+}
+
+// literalRuns splits the parsed pattern at every part that isn't
+// literal text. It always returns at least one run; the first run is
+// the literal prefix, and the last run is the literal suffix.
+//
+// Runs can be empty, e.g. when the pattern starts with a wildcard.
+//
+// It returns `false` if the pattern does not compile.
+func (g *Glob) literalRuns() ([]string, bool) {
+	if g.limitErr != nil {
+		return nil, false
+	}
+
+	retval := []string{""}
+	buf := strings.Builder{}
+	endRun := func() {
+		retval[len(retval)-1] = buf.String()
+		retval = append(retval, "")
+		buf.Reset()
+	}
+
+	for _, part := range g.patternParts {
+		if part.patternType != patternTypeStatic {
+			// wildcards, alternatives and numeric ranges all match
+			// text that we can't predict
+			endRun()
+			continue
+		}
+
+		atoms, err := parseStaticAtoms(part.pattern)
+		if err != nil {
+			return nil, false
+		}
+		for _, atom := range atoms {
+			if atom.isLiteral() {
+				buf.WriteRune(atom.ranges[0])
+			} else {
+				endRun()
+			}
+		}
+	}
+	retval[len(retval)-1] = buf.String()
+
+	return retval, true
+}
@@ -0,0 +1,216 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type literalsTestDataStruct struct {
+	pattern            string
+	options            []func(*Glob)
+	expectedPrefix     string
+	expectedSuffix     string
+	expectedSubstrings []string
+	expectedComplete   bool
+}
+
+var literalsTestDataSet = []literalsTestDataStruct{
+	{
+		pattern:            "www.example.com",
+		expectedPrefix:     "www.example.com",
+		expectedSuffix:     "www.example.com",
+		expectedSubstrings: []string{"www.example.com"},
+		expectedComplete:   true,
+	},
+	{
+		pattern:          "",
+		expectedComplete: true,
+	},
+	{
+		pattern:            "src/*.go",
+		expectedPrefix:     "src/",
+		expectedSuffix:     ".go",
+		expectedSubstrings: []string{"src/", ".go"},
+	},
+	{
+		pattern:            "*ab?cd[ef]gh*",
+		expectedSubstrings: []string{"ab", "cd", "gh"},
+	},
+	{
+		pattern:            "\\*.go*",
+		expectedPrefix:     "*.go",
+		expectedSubstrings: []string{"*.go"},
+	},
+	{
+		pattern:            "*\\?",
+		expectedSuffix:     "?",
+		expectedSubstrings: []string{"?"},
+	},
+	{
+		pattern:            "a+(b)|c^$[.]",
+		expectedPrefix:     "a+(b)|c^$.",
+		expectedSuffix:     "a+(b)|c^$.",
+		expectedSubstrings: []string{"a+(b)|c^$."},
+		expectedComplete:   true,
+	},
+	{
+		// `/**/` also matches a single `/`, so the globstar takes
+		// the slashes around it
+		pattern:            "lib/**/*.{js,py}",
+		options:            []func(*Glob){EditorConfigSyntax},
+		expectedPrefix:     "lib",
+		expectedSubstrings: []string{"lib", "."},
+	},
+	{
+		pattern:            "v{1..3}-final",
+		options:            []func(*Glob){EditorConfigSyntax},
+		expectedPrefix:     "v",
+		expectedSuffix:     "-final",
+		expectedSubstrings: []string{"v", "-final"},
+	},
+	{
+		// this pattern does not compile
+		pattern: "12345[",
+	},
+	{
+		// this pattern breaks a limit
+		pattern: "src/*.go",
+		options: []func(*Glob){MaxWildcards(0), MaxPatternLength(3)},
+	},
+}
+
+func TestGlobLiteralPrefix(t *testing.T) {
+	t.Parallel()
+
+	for _, testData := range literalsTestDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern, testData.options...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualPrefix, actualComplete := g.LiteralPrefix()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedPrefix, actualPrefix, testData.pattern)
+		assert.Equal(t, testData.expectedComplete, actualComplete, testData.pattern)
+	}
+}
+
+func TestGlobLiteralSuffix(t *testing.T) {
+	t.Parallel()
+
+	for _, testData := range literalsTestDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern, testData.options...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualSuffix, actualComplete := g.LiteralSuffix()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedSuffix, actualSuffix, testData.pattern)
+		assert.Equal(t, testData.expectedComplete, actualComplete, testData.pattern)
+	}
+}
+
+func TestGlobRequiredSubstrings(t *testing.T) {
+	t.Parallel()
+
+	for _, testData := range literalsTestDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern, testData.options...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualSubstrings, actualComplete := g.RequiredSubstrings()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedSubstrings, actualSubstrings, testData.pattern)
+		assert.Equal(t, testData.expectedComplete, actualComplete, testData.pattern)
+	}
+}
+
+func TestGlobRequiredSubstringsAreInEveryMatch(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern string
+		input   string
+	}{
+		{pattern: "src/*.go", input: "src/main.go"},
+		{pattern: "*ab?cd[ef]gh*", input: "xxabZcdeghyy"},
+		{pattern: "a*a*a", input: "aaa"},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern)
+		success, err := g.Match(testData.input)
+		assert.Nil(t, err)
+		assert.True(t, success)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		substrings, _ := g.RequiredSubstrings()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		for _, substring := range substrings {
+			assert.Contains(t, testData.input, substring, testData.pattern)
+		}
+	}
+}
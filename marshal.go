@@ -0,0 +1,386 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp/syntax"
+	"strings"
+)
+
+// SyntaxError is the error that `Compile()` and `Glob.UnmarshalText()`
+// return when a pattern does not compile. It says where in the pattern the problem
+// is.
+type SyntaxError struct {
+	// Pattern is the pattern that does not compile
+	Pattern string
+
+	// Offset is where the problem starts, in bytes from the start of
+	// Pattern
+	Offset int
+
+	// Msg describes the problem
+	Msg string
+}
+
+// Error returns a human-readable description of the problem.
+//
+// It meets the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("bad glob pattern '%s' at offset %d: %s", e.Pattern, e.Offset, e.Msg)
+}
+
+// Globs is a list of Globs that can be read from, and written to,
+// config files.
+//
+// As text, it is a comma-separated list of patterns. Commas inside
+// `[...]` and `{...}`, and escaped commas, don't separate patterns. As
+// JSON, it is an array of patterns.
+//
+// Call `NewGlobs()` to create a Globs that uses options, such as
+// EditorConfigSyntax or MaxWildcards(). The zero value is ready to
+// use, and uses UNIX shell syntax.
+type Globs struct {
+	// List holds a Glob for each pattern, in order
+	List []*Glob
+
+	options []func(*Glob)
+}
+
+// NewGlobs creates an empty Globs. Any options are passed to NewGlob()
+// for each pattern that is unmarshalled into the list.
+func NewGlobs(options ...func(*Glob)) *Globs {
+	return &Globs{options: options}
+}
+
+// MarshalText returns the Glob's pattern.
+//
+// It meets the encoding.TextMarshaler interface.
+func (g Glob) MarshalText() ([]byte, error) {
+	return []byte(g.pattern), nil
+}
+
+// UnmarshalText replaces the Glob with a new Glob for the given
+// pattern.
+//
+// Unlike NewGlob(), it checks the pattern straight away. It returns a
+// *SyntaxError if the pattern does not compile, or a *LimitError if it
+// breaks one of the Glob's limits.
+//
+// If the Glob was created with options, such as EditorConfigSyntax or
+// MaxWildcards(), the new Glob keeps them.
+//
+// It meets the encoding.TextUnmarshaler interface.
+func (g *Glob) UnmarshalText(text []byte) error {
+	var options []func(*Glob)
+	if g.parser != nil {
+		parser, syntax, limits := g.parser, g.syntax, g.limits
+		options = append(options, func(newGlob *Glob) {
+			newGlob.parser = parser
+			newGlob.syntax = syntax
+			newGlob.limits = limits
+		})
+	}
+
+	newGlob := NewGlob(string(text), options...)
+	err := newGlob.validate()
+	if err != nil {
+		return err
+	}
+
+	*g = *newGlob
+	return nil
+}
+
+// validate returns an error if the Glob's pattern does not compile
+func (g *Glob) validate() error {
+	if g.limitErr != nil {
+		return g.limitErr
+	}
+
+	_, err := g.getCompiledGlobForFlags(GlobMatchWholeString)
+	if err == nil {
+		return nil
+	}
+
+	// can we say where the problem is?
+	offset, msg, ok := findSyntaxError(g.pattern, g.syntax)
+	if !ok {
+		return err
+	}
+
+	return &SyntaxError{Pattern: g.pattern, Offset: offset, Msg: msg}
+}
+
+// findSyntaxError finds the first bracket expression in the pattern
+// that does not compile. It returns `false` if there isn't one.
+//
+// bracket expressions are the only part of a pattern that can stop it
+// compiling
+func findSyntaxError(pattern string, patternSyntax string) (int, string, bool) {
+	runes := []rune(pattern)
+	offset := 0
+	for i := 0; i < len(runes); i++ {
+		start := offset
+		offset += len(string(runes[i]))
+
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				offset += len(string(runes[i]))
+			}
+		case '[':
+			end := findBracketEnd(runes, i)
+
+			// EditorConfig treats these brackets as normal characters
+			if patternSyntax == editorConfigSyntax && (end < 0 || containsRune(runes[i:end], '/')) {
+				continue
+			}
+
+			if end < 0 {
+				return start, "missing closing ]", true
+			}
+
+			_, err := syntax.Parse(buildCharClass(runes[i+1:end]), syntax.Perl)
+			if err != nil {
+				if syntaxErr, ok := err.(*syntax.Error); ok {
+					return start, fmt.Sprintf("%s: `%s`", syntaxErr.Code, syntaxErr.Expr), true
+				}
+				return start, err.Error(), true
+			}
+
+			offset += len(string(runes[i+1 : end+1]))
+			i = end
+		}
+	}
+
+	return 0, "", false
+}
+
+// MarshalText returns the patterns as a comma-separated list. Any
+// comma that would split a pattern in two is escaped.
+//
+// A pattern that ends in a lone `\` would escape the comma after it,
+// so that `\` is doubled. `a\` and `a\\` match the same strings.
+//
+// UnmarshalText() turns the list back into the same patterns, with
+// two exceptions, which both match the same strings as before:
+//
+//   - a pattern that escapes its own comma, e.g. `a\,b`, comes back as
+//     `a,b`
+//   - a pattern that ends in a lone `\`, e.g. `a\`, comes back as `a\\`
+//
+// It meets the encoding.TextMarshaler interface.
+func (gs Globs) MarshalText() ([]byte, error) {
+	patterns := make([]string, 0, len(gs.List))
+	for _, g := range gs.List {
+		patterns = append(patterns, escapeListSeparators(g.pattern))
+	}
+
+	return []byte(strings.Join(patterns, ",")), nil
+}
+
+// UnmarshalText replaces the list with a Glob for each pattern in the
+// given comma-separated list.
+//
+// It checks each pattern straight away, and returns an error if any
+// of them do not compile, or break one of the list's limits. When it
+// returns an error, the list is left alone.
+//
+// It meets the encoding.TextUnmarshaler interface.
+func (gs *Globs) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		gs.List = nil
+		return nil
+	}
+
+	return gs.unmarshalPatterns(splitPatternList(string(text)))
+}
+
+// MarshalJSON returns the patterns as a JSON array of strings.
+//
+// It meets the json.Marshaler interface.
+func (gs Globs) MarshalJSON() ([]byte, error) {
+	patterns := make([]string, 0, len(gs.List))
+	for _, g := range gs.List {
+		patterns = append(patterns, g.pattern)
+	}
+
+	return json.Marshal(patterns)
+}
+
+// UnmarshalJSON replaces the list with a Glob for each pattern in the
+// given JSON array of strings. It also accepts a single string, which
+// it treats as a comma-separated list.
+//
+// It checks each pattern straight away, and returns an error if any
+// of them do not compile, or break one of the list's limits. When it
+// returns an error, the list is left alone.
+//
+// It meets the json.Unmarshaler interface.
+func (gs *Globs) UnmarshalJSON(data []byte) error {
+	var patterns []string
+	err := json.Unmarshal(data, &patterns)
+	if err == nil {
+		return gs.unmarshalPatterns(patterns)
+	}
+
+	var list string
+	if json.Unmarshal(data, &list) == nil {
+		return gs.UnmarshalText([]byte(list))
+	}
+
+	return err
+}
+
+// unmarshalPatterns replaces the list with a Glob for each of the
+// given patterns
+func (gs *Globs) unmarshalPatterns(patterns []string) error {
+	retval := make([]*Glob, 0, len(patterns))
+	for i, pattern := range patterns {
+		g, err := gs.newGlob(pattern)
+		if err != nil {
+			return fmt.Errorf("glob %d: %w", i, err)
+		}
+		retval = append(retval, g)
+	}
+
+	gs.List = retval
+	return nil
+}
+
+// newGlob creates a Glob for the given pattern, using the list's
+// options, and checks it
+func (gs *Globs) newGlob(pattern string) (*Glob, error) {
+	return Compile(pattern, gs.options...)
+}
+
+// splitPatternList splits a comma-separated list of patterns. Commas
+// inside `[...]` and `{...}`, and escaped commas, don't split the list.
+//
+// It removes the backslash from any escaped comma that would otherwise
+// have split the list, so that it undoes escapeListSeparators().
+func splitPatternList(list string) []string {
+	var retval []string
+
+	runes := []rune(list)
+	pattern := strings.Builder{}
+	start := 0
+	scanListSeparators(runes, func(i int, escaped bool) {
+		pattern.WriteString(string(runes[start:i]))
+		if escaped {
+			// keep the comma, drop the backslash
+			start = i + 1
+			return
+		}
+
+		retval = append(retval, pattern.String())
+		pattern.Reset()
+		start = i + 1
+	})
+	pattern.WriteString(string(runes[start:]))
+
+	return append(retval, pattern.String())
+}
+
+// escapeListSeparators escapes any comma in the pattern that
+// splitPatternList() would split the pattern at
+func escapeListSeparators(pattern string) string {
+	runes := []rune(pattern)
+	retval := strings.Builder{}
+	start := 0
+	scanListSeparators(runes, func(i int, escaped bool) {
+		if escaped {
+			return
+		}
+
+		retval.WriteString(string(runes[start:i]))
+		retval.WriteString("\\,")
+		start = i + 1
+	})
+	retval.WriteString(string(runes[start:]))
+
+	// a lone '\' at the end would escape the comma that joins this
+	// pattern to the next one
+	if endsInLoneBackslash(runes) {
+		retval.WriteRune('\\')
+	}
+
+	return retval.String()
+}
+
+// endsInLoneBackslash returns `true` if the pattern ends in a '\' that
+// doesn't escape anything
+func endsInLoneBackslash(runes []rune) bool {
+	backslashes := 0
+	for i := len(runes) - 1; i >= 0 && runes[i] == '\\'; i-- {
+		backslashes++
+	}
+
+	return backslashes%2 == 1
+}
+
+// scanListSeparators calls found() for each comma that is not inside
+// `[...]` or `{...}`. For an escaped comma, it passes the position of
+// the backslash and `escaped` set to `true`.
+func scanListSeparators(runes []rune, found func(i int, escaped bool)) {
+	depth := 0
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if depth == 0 && i+1 < len(runes) && runes[i+1] == ',' {
+				found(i, true)
+			}
+			i++
+		case '[':
+			end := findBracketEnd(runes, i)
+			if end > 0 {
+				i = end
+			}
+		case '{':
+			depth++
+		case '}':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				found(i, false)
+			}
+		}
+	}
+}
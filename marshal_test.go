@@ -0,0 +1,521 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type configForTest struct {
+	Exclude []*Glob `json:"exclude"`
+	Include Globs   `json:"include"`
+	Main    Glob    `json:"main"`
+}
+
+func TestGlobsRoundTripThroughJSON(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	input := `{"exclude":["*.tmp","build/*"],"include":["src/*.go","[ab]*"],"main":"main.go"}`
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	var config configForTest
+	err := json.Unmarshal([]byte(input), &config)
+	assert.Nil(t, err)
+
+	actualResult, err := json.Marshal(config)
+	assert.Nil(t, err)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, input, string(actualResult))
+
+	success, err := config.Exclude[1].Match("build/app")
+	assert.Nil(t, err)
+	assert.True(t, success)
+
+	success, err = config.Include.List[1].Match("beta")
+	assert.Nil(t, err)
+	assert.True(t, success)
+}
+
+func TestGlobUnmarshalTextReturnsSyntaxErrorForInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern        string
+		options        []func(*Glob)
+		expectedOffset int
+		expectedMsg    string
+	}{
+		{
+			pattern:        "ab[z-a]cd",
+			expectedOffset: 2,
+			expectedMsg:    "invalid character class range: `z-a`",
+		},
+		{
+			pattern:        "12345[",
+			expectedOffset: 5,
+			expectedMsg:    "missing closing ]",
+		},
+		{
+			pattern:        "é\\[[[:foo:]]",
+			expectedOffset: 4,
+			expectedMsg:    "invalid character class range: `[:foo:]`",
+		},
+		{
+			pattern:        "a[b-a",
+			expectedOffset: 1,
+			expectedMsg:    "missing closing ]",
+		},
+		{
+			pattern:        "[a/]{x,[z-a]}",
+			options:        []func(*Glob){EditorConfigSyntax},
+			expectedOffset: 7,
+			expectedMsg:    "invalid character class range: `z-a`",
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob("", testData.options...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		err := g.UnmarshalText([]byte(testData.pattern))
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		var syntaxErr *SyntaxError
+		if !assert.True(t, errors.As(err, &syntaxErr), testData.pattern) {
+			continue
+		}
+		assert.Equal(t, testData.pattern, syntaxErr.Pattern)
+		assert.Equal(t, testData.expectedOffset, syntaxErr.Offset, testData.pattern)
+		assert.Equal(t, testData.expectedMsg, syntaxErr.Msg, testData.pattern)
+	}
+}
+
+func TestSyntaxErrorSaysWhereTheProblemIs(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	err := &SyntaxError{Pattern: "12345[", Offset: 5, Msg: "missing closing ]"}
+	expectedResult := "bad glob pattern '12345[' at offset 5: missing closing ]"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult := err.Error()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestGlobUnmarshalTextKeepsOptions(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	g := NewGlob("", EditorConfigSyntax, MaxWildcards(1))
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := g.UnmarshalText([]byte("*.{go,md}"))
+	limitErr := g.UnmarshalText([]byte("*/*.go"))
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, "*.{go,md}", g.Pattern())
+
+	success, err := g.Match("README.md")
+	assert.Nil(t, err)
+	assert.True(t, success)
+
+	var actualLimitErr *LimitError
+	assert.True(t, errors.As(limitErr, &actualLimitErr))
+
+	// a failed unmarshal leaves the Glob alone
+	assert.Equal(t, "*.{go,md}", g.Pattern())
+}
+
+func TestGlobsUnmarshalTextSplitsAtTopLevelCommas(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		input          string
+		expectedResult []string
+	}{
+		{
+			input:          "*.go,*.md",
+			expectedResult: []string{"*.go", "*.md"},
+		},
+		{
+			input:          "*.{go,md},[,;]*,a\\,b",
+			expectedResult: []string{"*.{go,md}", "[,;]*", "a,b"},
+		},
+		{
+			input:          "{a\\,b,c},[\\,]",
+			expectedResult: []string{"{a\\,b,c}", "[\\,]"},
+		},
+		{
+			input:          "a,,b",
+			expectedResult: []string{"a", "", "b"},
+		},
+		{
+			input:          "",
+			expectedResult: []string{},
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		var globs Globs
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		err := globs.UnmarshalText([]byte(testData.input))
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err)
+		actualResult := []string{}
+		for _, g := range globs.List {
+			actualResult = append(actualResult, g.Pattern())
+		}
+		assert.Equal(t, testData.expectedResult, actualResult, testData.input)
+	}
+}
+
+func TestGlobsMarshalTextEscapesCommasThatWouldSplitAPattern(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	globs := Globs{List: []*Glob{NewGlob("a,b"), NewGlob("{c,d}"), NewGlob("[,]")}}
+	expectedResult := "a\\,b,{c,d},[,]"
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := globs.MarshalText()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, string(actualResult))
+
+}
+
+func TestGlobsRoundTripThroughText(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []string{
+		"a,b",
+		"{c,d}",
+		"[,]",
+		"{a\\,b,c}",
+		"x\\\\,y",
+		"*.go",
+	}
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	globs := Globs{}
+	for _, pattern := range testDataSet {
+		globs.List = append(globs.List, NewGlob(pattern))
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	text, err := globs.MarshalText()
+	assert.Nil(t, err)
+
+	var roundTrip Globs
+	err = roundTrip.UnmarshalText(text)
+	assert.Nil(t, err)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	actualResult := []string{}
+	for _, g := range roundTrip.List {
+		actualResult = append(actualResult, g.Pattern())
+	}
+	assert.Equal(t, testDataSet, actualResult, string(text))
+}
+
+func TestGlobsUnmarshalTextRemovesBackslashFromEscapedListSeparator(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+	//
+	// a pattern's own escaped comma comes back unescaped; both forms
+	// match the same strings
+
+	globs := Globs{List: []*Glob{NewGlob("x\\,y")}}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	text, err := globs.MarshalText()
+	assert.Nil(t, err)
+
+	var roundTrip Globs
+	err = roundTrip.UnmarshalText(text)
+	assert.Nil(t, err)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	if assert.Len(t, roundTrip.List, 1) {
+		assert.Equal(t, "x,y", roundTrip.List[0].Pattern())
+
+		success, err := roundTrip.List[0].Match("x,y")
+		assert.Nil(t, err)
+		assert.True(t, success)
+	}
+}
+
+func TestGlobsUnmarshalJSONAcceptsCommaSeparatedString(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	var globs Globs
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := json.Unmarshal([]byte(`"*.go,*.md"`), &globs)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	if assert.Len(t, globs.List, 2) {
+		assert.Equal(t, "*.go", globs.List[0].Pattern())
+		assert.Equal(t, "*.md", globs.List[1].Pattern())
+	}
+}
+
+func TestGlobsUnmarshalJSONReturnsErrorForInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	var config configForTest
+	input := `{"include":["*.go","ab[z-a]"]}`
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := json.Unmarshal([]byte(input), &config)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	var syntaxErr *SyntaxError
+	if assert.True(t, errors.As(err, &syntaxErr)) {
+		assert.Equal(t, 2, syntaxErr.Offset)
+	}
+	assert.Contains(t, err.Error(), "glob 1: ")
+}
+
+func TestGlobUnmarshalJSONReturnsErrorForInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	var config configForTest
+	input := `{"exclude":["ok","12345["]}`
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := json.Unmarshal([]byte(input), &config)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	var syntaxErr *SyntaxError
+	if assert.True(t, errors.As(err, &syntaxErr)) {
+		assert.Equal(t, 5, syntaxErr.Offset)
+	}
+}
+
+func TestGlobsUnmarshalJSONUsesOptionsFromNewGlobs(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	config := struct {
+		Include *Globs `json:"include"`
+	}{
+		Include: NewGlobs(EditorConfigSyntax, MaxWildcards(2)),
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := json.Unmarshal([]byte(`{"include":["src/**/*.go"]}`), &config)
+	limitErr := json.Unmarshal([]byte(`{"include":["*.go","*/*/*.go"]}`), &config)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	if assert.Len(t, config.Include.List, 1) {
+		// only EditorConfig's globstar can cross the slashes
+		success, err := config.Include.List[0].Match("src/cmd/app/main.go")
+		assert.Nil(t, err)
+		assert.True(t, success)
+	}
+
+	var actualLimitErr *LimitError
+	if assert.True(t, errors.As(limitErr, &actualLimitErr)) {
+		assert.Equal(t, LimitWildcards, actualLimitErr.Limit)
+		assert.Equal(t, 2, actualLimitErr.Max)
+	}
+	assert.Contains(t, limitErr.Error(), "glob 1: ")
+
+	// a failed unmarshal leaves the list alone
+	if assert.Len(t, config.Include.List, 1) {
+		assert.Equal(t, "src/**/*.go", config.Include.List[0].Pattern())
+	}
+}
+
+func TestGlobUnmarshalJSONUsesOptionsFromExistingGlob(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	config := struct {
+		Main *Glob `json:"main"`
+	}{
+		Main: NewGlob("", MaxWildcards(1)),
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	err := json.Unmarshal([]byte(`{"main":"*/*.go"}`), &config)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	var actualLimitErr *LimitError
+	if assert.True(t, errors.As(err, &actualLimitErr)) {
+		assert.Equal(t, LimitWildcards, actualLimitErr.Limit)
+	}
+}
+
+func TestGlobsRoundTripThroughTextKeepsTrailingBackslash(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	globs := Globs{List: []*Glob{NewGlob("a\\"), NewGlob("b"), NewGlob("c\\\\\\")}}
+	expectedResult := []string{"a\\\\", "b", "c\\\\\\\\"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	text, err := globs.MarshalText()
+	assert.Nil(t, err)
+
+	var roundTrip Globs
+	err = roundTrip.UnmarshalText(text)
+	assert.Nil(t, err)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, "a\\\\,b,c\\\\\\\\", string(text))
+
+	actualResult := []string{}
+	for _, g := range roundTrip.List {
+		actualResult = append(actualResult, g.Pattern())
+	}
+	assert.Equal(t, expectedResult, actualResult)
+
+	// the doubled backslash matches the same strings
+	for i, g := range roundTrip.List {
+		original := globs.List[i]
+		for _, input := range []string{"a\\", "b", "c\\", "c\\\\"} {
+			expected, err := original.Match(input)
+			assert.Nil(t, err)
+			actual, err := g.Match(input)
+			assert.Nil(t, err)
+			assert.Equal(t, expected, actual, "%q vs %q on %q", original.Pattern(), g.Pattern(), input)
+		}
+	}
+}
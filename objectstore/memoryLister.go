@@ -0,0 +1,112 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package objectstore
+
+import (
+	"sort"
+	"strings"
+)
+
+// MemoryLister is a KeyLister that keeps its keys in memory. It is
+// handy for testing code that works with a real object store.
+//
+// Call `NewMemoryLister()` to create your MemoryLister.
+type MemoryLister struct {
+	keys []string
+}
+
+// NewMemoryLister creates a MemoryLister that holds the given keys.
+func NewMemoryLister(keys ...string) *MemoryLister {
+	retval := MemoryLister{}
+	retval.Add(keys...)
+
+	return &retval
+}
+
+// Add puts more keys into the MemoryLister. Any keys that it already
+// holds are ignored.
+func (l *MemoryLister) Add(keys ...string) {
+	seen := make(map[string]bool, len(l.keys))
+	for _, key := range l.keys {
+		seen[key] = true
+	}
+
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		l.keys = append(l.keys, key)
+	}
+
+	sort.Strings(l.keys)
+}
+
+// Keys returns every key in the MemoryLister, in sorted order.
+func (l *MemoryLister) Keys() []string {
+	return append([]string(nil), l.keys...)
+}
+
+// ListKeys returns every key that starts with prefix, in sorted
+// order. It never returns an error.
+//
+// It meets the KeyLister interface.
+func (l *MemoryLister) ListKeys(prefix, delimiter string) ([]string, []string, error) {
+	var keys, commonPrefixes []string
+
+	// the keys are sorted, so we can skip straight to the first one
+	// with our prefix
+	start := sort.SearchStrings(l.keys, prefix)
+	for _, key := range l.keys[start:] {
+		if !strings.HasPrefix(key, prefix) {
+			break
+		}
+
+		if delimiter != "" {
+			i := strings.Index(key[len(prefix):], delimiter)
+			if i >= 0 {
+				commonPrefix := key[:len(prefix)+i+len(delimiter)]
+				if len(commonPrefixes) == 0 || commonPrefixes[len(commonPrefixes)-1] != commonPrefix {
+					commonPrefixes = append(commonPrefixes, commonPrefix)
+				}
+				continue
+			}
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, commonPrefixes, nil
+}
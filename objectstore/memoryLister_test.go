@@ -0,0 +1,111 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package objectstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryListerListKeysRollsUpCommonPrefixes(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		prefix                 string
+		delimiter              string
+		expectedKeys           []string
+		expectedCommonPrefixes []string
+	}{
+		{"", "/", []string{"README.md"}, []string{"logs/", "releases/"}},
+		{"logs/", "/", nil, []string{
+			"logs/2019-10-01/",
+			"logs/2019-10-02/",
+			"logs/2019-11-01/",
+			"logs/2020-01-01/",
+			"logs/archive/",
+		}},
+		{"logs/2019-10-01/", "/", []string{
+			"logs/2019-10-01/app.log",
+			"logs/2019-10-01/db.log",
+		}, nil},
+		{"logs/2019-1", "-", nil, []string{"logs/2019-10-", "logs/2019-11-"}},
+		{"releases/v1.1.0/", "", []string{
+			"releases/v1.1.0/extra/go_glob.zip",
+			"releases/v1.1.0/go_glob.tar.gz",
+		}, nil},
+		{"missing/", "/", nil, nil},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		lister := NewMemoryLister(exampleKeys...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualKeys, actualCommonPrefixes, err := lister.ListKeys(testData.prefix, testData.delimiter)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err)
+		assert.Equal(t, testData.expectedKeys, actualKeys, testData.prefix)
+		assert.Equal(t, testData.expectedCommonPrefixes, actualCommonPrefixes, testData.prefix)
+	}
+}
+
+func TestMemoryListerAddIgnoresDuplicateKeys(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	lister := NewMemoryLister("b", "a")
+	expectedResult := []string{"a", "b", "c"}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	lister.Add("c", "a")
+	actualResult := lister.Keys()
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Equal(t, expectedResult, actualResult)
+}
@@ -0,0 +1,258 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+// Package objectstore expands glob patterns against flat keyspaces,
+// such as the ones in S3 or GCS buckets.
+//
+// Keys are split into segments on a delimiter, usually "/". Expand()
+// lists one level of the keyspace at a time, and only descends into
+// the common prefixes that match the pattern. It uses the literal text
+// in front of each wildcard as the listing prefix, so that it makes as
+// few list calls as possible.
+package objectstore
+
+import (
+	"sort"
+	"strings"
+
+	glob "github.com/ganbarodigital/go_glob"
+)
+
+// KeyLister is anything that can list the keys in a flat keyspace.
+type KeyLister interface {
+	// ListKeys returns every key that starts with prefix.
+	//
+	// If delimiter is not empty, any key that contains delimiter after
+	// the prefix is rolled up into a common prefix instead. Each
+	// common prefix runs up to, and includes, the first delimiter
+	// after the prefix.
+	//
+	// Implementations must return the whole listing, fetching as many
+	// pages as they need to.
+	ListKeys(prefix, delimiter string) (keys []string, commonPrefixes []string, err error)
+}
+
+// segment is one delimiter-separated part of a pattern
+type segment struct {
+	// text is the segment, exactly as it appears in the pattern
+	text string
+
+	// prefix is the unescaped literal text before the segment's first
+	// wildcard
+	prefix string
+
+	// literal is `true` if the segment has no wildcards at all
+	literal bool
+
+	// globstar is `true` if the segment is `**`
+	globstar bool
+
+	glob *glob.Glob
+}
+
+// Expand returns every key in the keyspace that matches pattern, in
+// sorted order.
+//
+// pattern is split into segments on delimiter. A segment can use any
+// of the wildcards that NewGlob() supports, but it never matches
+// across a delimiter. A segment that is exactly `**` matches zero or
+// more whole segments. If delimiter is empty, the whole pattern is
+// matched against each key.
+//
+// Returns an error if any segment is not a valid glob pattern, or if
+// the lister returns an error.
+func Expand(lister KeyLister, pattern, delimiter string) ([]string, error) {
+	segments, err := parseSegments(pattern, delimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	e := expander{
+		lister:    lister,
+		delimiter: delimiter,
+	}
+	err = e.expand("", segments)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(e.matches)
+	return e.matches, nil
+}
+
+// parseSegments splits the pattern into segments, and compiles each
+// one
+func parseSegments(pattern, delimiter string) ([]segment, error) {
+	parts := []string{pattern}
+	if delimiter != "" {
+		parts = strings.Split(pattern, delimiter)
+	}
+
+	retval := make([]segment, len(parts))
+	for i, part := range parts {
+		prefix, literal := literalPrefix(part)
+		retval[i] = segment{
+			text:     part,
+			prefix:   prefix,
+			literal:  literal,
+			globstar: part == "**" && delimiter != "",
+			glob:     glob.NewGlob(part),
+		}
+
+		// we want to find out about any errors now, rather than
+		// halfway through a listing
+		_, err := retval[i].glob.Match("")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return retval, nil
+}
+
+// literalPrefix returns the unescaped text before the first wildcard
+// in the pattern, and whether or not the pattern has any wildcards
+func literalPrefix(pattern string) (string, bool) {
+	retval := strings.Builder{}
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+			}
+			retval.WriteRune(runes[i])
+		case '*', '?', '[':
+			return retval.String(), false
+		default:
+			retval.WriteRune(runes[i])
+		}
+	}
+
+	return retval.String(), true
+}
+
+// expander walks the keyspace for a single call to Expand()
+type expander struct {
+	lister    KeyLister
+	delimiter string
+	matches   []string
+}
+
+// expand finds the keys under prefix that match the given segments
+func (e *expander) expand(prefix string, segments []segment) error {
+	// literal segments don't need a list call of their own; they
+	// just make the prefix longer
+	for len(segments) > 1 && segments[0].literal {
+		prefix += segments[0].prefix + e.delimiter
+		segments = segments[1:]
+	}
+
+	// a globstar can match any number of levels, so we list everything
+	// below it in one go, and filter the results
+	if segments[0].globstar {
+		keys, _, err := e.lister.ListKeys(prefix, "")
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			names := strings.Split(key[len(prefix):], e.delimiter)
+			if matchSegments(names, segments) {
+				e.matches = append(e.matches, key)
+			}
+		}
+		return nil
+	}
+
+	keys, commonPrefixes, err := e.lister.ListKeys(prefix+segments[0].prefix, e.delimiter)
+	if err != nil {
+		return err
+	}
+
+	// are we looking at the last segment?
+	if len(segments) == 1 {
+		for _, key := range keys {
+			if matchSegment(key[len(prefix):], segments[0]) {
+				e.matches = append(e.matches, key)
+			}
+		}
+		return nil
+	}
+
+	for _, commonPrefix := range commonPrefixes {
+		name := commonPrefix[len(prefix) : len(commonPrefix)-len(e.delimiter)]
+		if !matchSegment(name, segments[0]) {
+			continue
+		}
+
+		err = e.expand(commonPrefix, segments[1:])
+		if err != nil {
+			return err
+		}
+	}
+
+	// all done
+	return nil
+}
+
+// matchSegments returns `true` if the list of names matches the list
+// of segments
+func matchSegments(names []string, segments []segment) bool {
+	if len(segments) == 0 {
+		return len(names) == 0
+	}
+
+	if segments[0].globstar {
+		for i := 0; i <= len(names); i++ {
+			if matchSegments(names[i:], segments[1:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(names) == 0 || !matchSegment(names[0], segments[0]) {
+		return false
+	}
+
+	return matchSegments(names[1:], segments[1:])
+}
+
+// matchSegment returns `true` if the name matches the segment
+func matchSegment(name string, seg segment) bool {
+	// we've already checked that the glob compiles
+	success, _ := seg.glob.Match(name)
+	return success
+}
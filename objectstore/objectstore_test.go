@@ -0,0 +1,247 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package objectstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingLister records each call to ListKeys()
+type countingLister struct {
+	lister KeyLister
+	calls  []string
+}
+
+func (l *countingLister) ListKeys(prefix, delimiter string) ([]string, []string, error) {
+	l.calls = append(l.calls, prefix+" "+delimiter)
+	return l.lister.ListKeys(prefix, delimiter)
+}
+
+// failingLister always returns an error
+type failingLister struct{}
+
+func (l failingLister) ListKeys(prefix, delimiter string) ([]string, []string, error) {
+	return nil, nil, errors.New("bucket not found")
+}
+
+var exampleKeys = []string{
+	"README.md",
+	"logs/2019-10-01/app.log",
+	"logs/2019-10-01/db.log",
+	"logs/2019-10-02/app.log",
+	"logs/2019-11-01/app.log",
+	"logs/2020-01-01/app.log",
+	"logs/archive/2018/app.log.gz",
+	"releases/v1.0.0/go_glob.tar.gz",
+	"releases/v1.0.0/checksums.txt",
+	"releases/v1.1.0/go_glob.tar.gz",
+	"releases/v1.1.0/extra/go_glob.zip",
+	"releases/v1.*/notes.txt",
+}
+
+func TestExpandReturnsMatchingKeys(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern        string
+		expectedResult []string
+	}{
+		{"README.md", []string{"README.md"}},
+		{"*.md", []string{"README.md"}},
+		{"missing.md", nil},
+		{
+			"logs/2019-10-*/app.log",
+			[]string{"logs/2019-10-01/app.log", "logs/2019-10-02/app.log"},
+		},
+		{
+			"logs/2019-*/*.log",
+			[]string{
+				"logs/2019-10-01/app.log",
+				"logs/2019-10-01/db.log",
+				"logs/2019-10-02/app.log",
+				"logs/2019-11-01/app.log",
+			},
+		},
+		{"logs/*", nil},
+		{
+			"releases/v1.?.0/*.tar.gz",
+			[]string{"releases/v1.0.0/go_glob.tar.gz", "releases/v1.1.0/go_glob.tar.gz"},
+		},
+		{
+			"releases/**/go_glob.*",
+			[]string{
+				"releases/v1.0.0/go_glob.tar.gz",
+				"releases/v1.1.0/extra/go_glob.zip",
+				"releases/v1.1.0/go_glob.tar.gz",
+			},
+		},
+		{
+			"**/app.log",
+			[]string{
+				"logs/2019-10-01/app.log",
+				"logs/2019-10-02/app.log",
+				"logs/2019-11-01/app.log",
+				"logs/2020-01-01/app.log",
+			},
+		},
+		{"logs/**", exampleKeys[1:7]},
+		{"releases/v1.\\*/notes.txt", []string{"releases/v1.*/notes.txt"}},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		lister := NewMemoryLister(exampleKeys...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, err := Expand(lister, testData.pattern, "/")
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, testData.pattern)
+		assert.Equal(t, testData.expectedResult, actualResult, testData.pattern)
+	}
+}
+
+func TestExpandUsesLiteralPrefixToPruneListCalls(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern       string
+		expectedCalls []string
+	}{
+		{"README.md", []string{"README.md /"}},
+		{"logs/2019-10-*/app.log", []string{
+			"logs/2019-10- /",
+			"logs/2019-10-01/app.log /",
+			"logs/2019-10-02/app.log /",
+		}},
+		{"logs/202?-*/*.log", []string{
+			"logs/202 /",
+			"logs/2020-01-01/ /",
+		}},
+		{"releases/**/*.zip", []string{"releases/ "}},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		lister := countingLister{lister: NewMemoryLister(exampleKeys...)}
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		_, err := Expand(&lister, testData.pattern, "/")
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Nil(t, err, testData.pattern)
+		assert.Equal(t, testData.expectedCalls, lister.calls, testData.pattern)
+	}
+}
+
+func TestExpandMatchesWholeKeysWhenDelimiterIsEmpty(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	lister := countingLister{lister: NewMemoryLister(exampleKeys...)}
+	expectedResult := []string{
+		"logs/2019-10-01/app.log",
+		"logs/2019-10-02/app.log",
+		"logs/2019-11-01/app.log",
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := Expand(&lister, "logs/2019*app.log", "")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedResult, actualResult)
+	assert.Equal(t, []string{"logs/2019 "}, lister.calls)
+}
+
+func TestExpandReturnsErrorWhenPatternInvalid(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	lister := countingLister{lister: NewMemoryLister(exampleKeys...)}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := Expand(&lister, "logs/12345[/*.log", "/")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Error(t, err)
+	assert.Nil(t, actualResult)
+	assert.Empty(t, lister.calls)
+}
+
+func TestExpandReturnsErrorFromLister(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	actualResult, err := Expand(failingLister{}, "logs/*/app.log", "/")
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Error(t, err)
+	assert.Nil(t, actualResult)
+}
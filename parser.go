@@ -34,13 +34,21 @@
 
 package glob
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+)
 
 const (
 	patternTypeNone = iota
 	patternTypeStatic
 	patternTypeSingleMatch
 	patternTypeMultiMatch
+	patternTypeSegmentSingleMatch
+	patternTypeSegmentMultiMatch
+	patternTypeGlobStar
+	patternTypeAlternatives
+	patternTypeNumericRange
 )
 
 const (
@@ -54,6 +62,9 @@ const (
 type parsedPattern struct {
 	pattern     string
 	patternType int
+
+	// alternatives holds the choices for a patternTypeAlternatives part
+	alternatives [][]parsedPattern
 }
 
 func parsePattern(pattern string) []parsedPattern {
@@ -67,11 +78,17 @@ func parsePattern(pattern string) []parsedPattern {
 	patternBuf := strings.Builder{}
 
 	// iterate over the runes
-	for _, p := range pattern {
+	//
+	// we need random access, so that we can look ahead for the end
+	// of any bracket expressions
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		p := runes[i]
+
 		// special case - have we just seen the start of an escape
 		// sequence?
 		if lastTokenType == patternTokenEscape {
-			patternBuf.WriteRune(p)
+			patternBuf.WriteString(regexp.QuoteMeta(string(p)))
 			lastTokenType = patternTokenStatic
 			continue
 		}
@@ -80,7 +97,6 @@ func parsePattern(pattern string) []parsedPattern {
 		switch p {
 		case '\\':
 			currentTokenType = patternTokenEscape
-			patternBuf.WriteRune(p)
 		case '?':
 			currentTokenType = patternTokenSingleMatch
 			if lastTokenType == patternTokenStatic {
@@ -122,6 +138,18 @@ func parsePattern(pattern string) []parsedPattern {
 					patternType: patternTypeMultiMatch,
 				},
 			)
+		case '[':
+			currentTokenType = patternTokenStatic
+
+			// an unterminated '[' is left as-is, and the regex compiler
+			// will reject it
+			end := findBracketEnd(runes, i)
+			if end < 0 {
+				patternBuf.WriteRune(p)
+			} else {
+				patternBuf.WriteString(buildCharClass(runes[i+1 : end]))
+				i = end
+			}
 		case '.':
 			// this character needs escaping
 			currentTokenType = patternTokenStatic
@@ -138,6 +166,11 @@ func parsePattern(pattern string) []parsedPattern {
 			// this character needs escaping
 			currentTokenType = patternTokenStatic
 			patternBuf.WriteString("\\{")
+		case ')', '|', '^', '$':
+			// these characters need escaping too
+			currentTokenType = patternTokenStatic
+			patternBuf.WriteRune('\\')
+			patternBuf.WriteRune(p)
 		default:
 			currentTokenType = patternTokenStatic
 			patternBuf.WriteRune(p)
@@ -146,6 +179,13 @@ func parsePattern(pattern string) []parsedPattern {
 		lastTokenType = currentTokenType
 	}
 
+	// special case - a trailing backslash has nothing to escape, so
+	// the shell treats it as a normal character
+	if lastTokenType == patternTokenEscape {
+		patternBuf.WriteString("\\\\")
+		lastTokenType = patternTokenStatic
+	}
+
 	// deal with last char in the pattern
 	if lastTokenType == patternTokenStatic {
 		retval = append(
@@ -161,3 +201,112 @@ func parsePattern(pattern string) []parsedPattern {
 	// all done
 	return retval
 }
+
+// findBracketEnd returns the position of the ']' that closes the
+// bracket expression starting at runes[start], or -1 if the bracket
+// expression is never closed
+func findBracketEnd(runes []rune, start int) int {
+	i := start + 1
+
+	// skip over any negation
+	if i < len(runes) && (runes[i] == '!' || runes[i] == '^') {
+		i++
+	}
+
+	// a ']' at the very start is a member of the set
+	if i < len(runes) && runes[i] == ']' {
+		i++
+	}
+
+	for ; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case '[':
+			// skip over any [:class:], [=c=] or [.c.]
+			end := findClassEnd(runes, i)
+			if end > 0 {
+				i = end
+			}
+		case ']':
+			return i
+		}
+	}
+
+	// if we get here, there is no closing bracket
+	return -1
+}
+
+// findClassEnd returns the position of the ']' that closes a [:class:],
+// [=c=] or [.c.] expression starting at runes[start], or -1 if there
+// isn't one
+func findClassEnd(runes []rune, start int) int {
+	if start+1 >= len(runes) {
+		return -1
+	}
+
+	term := runes[start+1]
+	if term != ':' && term != '=' && term != '.' {
+		return -1
+	}
+
+	for i := start + 2; i+1 < len(runes); i++ {
+		if runes[i] == term && runes[i+1] == ']' {
+			return i + 1
+		}
+	}
+
+	return -1
+}
+
+// buildCharClass turns the contents of a glob bracket expression into
+// the equivalent Golang regex character class
+func buildCharClass(members []rune) string {
+	retval := strings.Builder{}
+	retval.WriteRune('[')
+
+	i := 0
+	if len(members) > 0 && (members[0] == '!' || members[0] == '^') {
+		retval.WriteRune('^')
+		i++
+	}
+
+	for ; i < len(members); i++ {
+		m := members[i]
+		switch {
+		case m == '\\' && i+1 < len(members):
+			i++
+			writeCharClassMember(&retval, members[i])
+		case m == '[' && findClassEnd(members, i) > 0:
+			end := findClassEnd(members, i)
+			if members[i+1] == ':' {
+				// Golang supports the same named classes as the shell
+				retval.WriteString(string(members[i : end+1]))
+			} else {
+				// equivalence classes and collating symbols both
+				// boil down to the characters inside them
+				for _, r := range members[i+2 : end-1] {
+					writeCharClassMember(&retval, r)
+				}
+			}
+			i = end
+		case m == '-':
+			// keep ranges working
+			retval.WriteRune(m)
+		default:
+			writeCharClassMember(&retval, m)
+		}
+	}
+
+	retval.WriteRune(']')
+	return retval.String()
+}
+
+// writeCharClassMember adds a single character to a regex character
+// class, escaping it if the regex compiler would treat it as special
+func writeCharClassMember(buf *strings.Builder, r rune) {
+	if strings.ContainsRune("\\[]^-", r) {
+		buf.WriteRune('\\')
+	}
+	buf.WriteRune(r)
+}
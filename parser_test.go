@@ -195,7 +195,83 @@ func TestParsePattern(t *testing.T) {
 					patternType: patternTypeMultiMatch,
 				},
 				{
-					pattern:     "\\(go)",
+					pattern:     "\\(go\\)",
+					patternType: patternTypeStatic,
+				},
+			},
+		},
+		{
+			input: "a|b^c$",
+			expectedResult: []parsedPattern{
+				{
+					pattern:     "a\\|b\\^c\\$",
+					patternType: patternTypeStatic,
+				},
+			},
+		},
+		{
+			input: "\\a\\.",
+			expectedResult: []parsedPattern{
+				{
+					pattern:     "a\\.",
+					patternType: patternTypeStatic,
+				},
+			},
+		},
+		{
+			input: "abc\\",
+			expectedResult: []parsedPattern{
+				{
+					pattern:     "abc\\\\",
+					patternType: patternTypeStatic,
+				},
+			},
+		},
+		{
+			input: "[!abc]*",
+			expectedResult: []parsedPattern{
+				{
+					pattern:     "[^abc]",
+					patternType: patternTypeStatic,
+				},
+				{
+					pattern:     "*",
+					patternType: patternTypeMultiMatch,
+				},
+			},
+		},
+		{
+			input: "[*?]",
+			expectedResult: []parsedPattern{
+				{
+					pattern:     "[*?]",
+					patternType: patternTypeStatic,
+				},
+			},
+		},
+		{
+			input: "[]a-z]",
+			expectedResult: []parsedPattern{
+				{
+					pattern:     "[\\]a-z]",
+					patternType: patternTypeStatic,
+				},
+			},
+		},
+		{
+			input: "[[:digit:]\\]x]",
+			expectedResult: []parsedPattern{
+				{
+					pattern:     "[[:digit:]\\]x]",
+					patternType: patternTypeStatic,
+				},
+			},
+		},
+		{
+			input: "12345[",
+			expectedResult: []parsedPattern{
+				{
+					pattern:     "12345[",
 					patternType: patternTypeStatic,
 				},
 			},
@@ -47,29 +47,75 @@ func buildRegex(pattern []parsedPattern, flags int) string {
 		rawRegex.WriteRune('^')
 	}
 
+	writeRegexParts(&rawRegex, pattern, flags, true)
+
+	if flags&GlobAnchorSuffix != 0 {
+		rawRegex.WriteRune('$')
+	}
+
+	return rawRegex.String()
+}
+
+// writeRegexParts adds the regex for each part of the parsed pattern
+// to the given buffer
+//
+// endsPattern is `true` if nothing in the pattern comes after the last
+// of these parts
+func writeRegexParts(rawRegex *strings.Builder, pattern []parsedPattern, flags int, endsPattern bool) {
 	for pos, part := range pattern {
+		// special case - a wildcard at the end of the pattern must always
+		// be longest match
+		longest := flags&GlobLongestMatch != 0 || (endsPattern && pos == len(pattern)-1)
+
 		switch part.patternType {
 		case patternTypeSingleMatch:
 			rawRegex.WriteString(".")
 		case patternTypeMultiMatch:
-			// special case - a * at the end of the pattern must always
-			// be longest match
-			if pos == len(pattern)-1 {
-				rawRegex.WriteString(".*")
-			} else if flags&GlobLongestMatch != 0 {
+			if longest {
 				rawRegex.WriteString(".*")
 			} else {
 				rawRegex.WriteString(".*?")
 			}
 		case patternTypeStatic:
-			// TODO - we need to escape characters in the pattern
 			rawRegex.WriteString(part.pattern)
+		case patternTypeSegmentSingleMatch:
+			rawRegex.WriteString("[^/]")
+		case patternTypeSegmentMultiMatch:
+			// same rules as patternTypeMultiMatch
+			if longest {
+				rawRegex.WriteString("[^/]*")
+			} else {
+				rawRegex.WriteString("[^/]*?")
+			}
+		case patternTypeGlobStar:
+			// '**' matches any character, including newlines; '[^/]'
+			// does too, so '*' and '?' match them as well
+			//
+			// special case - '/**/' also matches a single '/'
+			switch {
+			case part.pattern == "/**/" && longest:
+				rawRegex.WriteString("/(?s:.*/)?")
+			case part.pattern == "/**/":
+				rawRegex.WriteString("/(?s:.*?/)??")
+			case longest:
+				rawRegex.WriteString("(?s:.*)")
+			default:
+				rawRegex.WriteString("(?s:.*?)")
+			}
+		case patternTypeAlternatives:
+			// the closing '}' comes after each alternative, so none of
+			// them end the pattern
+			rawRegex.WriteString("(?:")
+			for i, alternative := range part.alternatives {
+				if i > 0 {
+					rawRegex.WriteRune('|')
+				}
+				writeRegexParts(rawRegex, alternative, flags, false)
+			}
+			rawRegex.WriteRune(')')
+		case patternTypeNumericRange:
+			lo, hi, _ := parseNumericRange(part.pattern)
+			rawRegex.WriteString(buildNumericRangeRegex(lo, hi))
 		}
 	}
-
-	if flags&GlobAnchorSuffix != 0 {
-		rawRegex.WriteRune('$')
-	}
-
-	return rawRegex.String()
 }
@@ -0,0 +1,93 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+// Subsumes returns `true` if every string that b matches is also
+// matched by a. If a is checked before b in a list of rules, b can
+// never be reached.
+//
+// It compares what Match() would do, and the answer is exact. A Glob
+// whose pattern does not compile matches nothing.
+func Subsumes(a, b *Glob) bool {
+	nfaB := newNFA(b)
+	if nfaB == nil {
+		// b matches nothing, so a matches everything that b does
+		return true
+	}
+
+	// a Glob that doesn't compile matches nothing, which we can model
+	// with an automaton that has no states
+	nfaA := newNFA(a)
+	if nfaA == nil {
+		nfaA = newEmptyNFA()
+	}
+
+	_, found := findCounterexample(nfaA, nfaB)
+	return !found
+}
+
+// Overlaps returns `true` if there is at least one string that both a
+// and b match. It also returns the shortest such string.
+//
+// It compares what Match() would do, and the answer is exact. A Glob
+// whose pattern does not compile matches nothing.
+func Overlaps(a, b *Glob) (bool, string) {
+	nfaA := newNFA(a)
+	nfaB := newNFA(b)
+	if nfaA == nil || nfaB == nil {
+		return false, ""
+	}
+
+	example, found := productSearch(
+		nfaA,
+		nfaB,
+		func(stateA, stateB nfaState) bool {
+			return len(stateA) > 0 && len(stateB) > 0
+		},
+		func(stateA, stateB nfaState) bool {
+			return nfaA.accepts(stateA) && nfaB.accepts(stateB)
+		},
+	)
+
+	return found, example
+}
+
+// Equivalent returns `true` if a and b match exactly the same strings.
+//
+// It compares what Match() would do, and the answer is exact. A Glob
+// whose pattern does not compile matches nothing.
+func Equivalent(a, b *Glob) bool {
+	return Subsumes(a, b) && Subsumes(b, a)
+}
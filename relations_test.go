@@ -0,0 +1,180 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type relationTestDataStruct struct {
+	a              *Glob
+	b              *Glob
+	expectedResult bool
+}
+
+func TestSubsumes(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []relationTestDataStruct{
+		{NewGlob("*"), NewGlob("*.go"), true},
+		{NewGlob("*.go"), NewGlob("*"), false},
+		{NewGlob("*.go"), NewGlob("main.go"), true},
+		{NewGlob("main.go"), NewGlob("*.go"), false},
+		{NewGlob("src/*"), NewGlob("src/*.go"), true},
+		{NewGlob("[a-z]*"), NewGlob("[b-c]x"), true},
+		{NewGlob("[!a]*"), NewGlob("b*"), true},
+		{NewGlob("[!a]*"), NewGlob("*"), false},
+		{NewGlob("?"), NewGlob("[[:digit:]]"), true},
+		{NewGlob("a*"), NewGlob("a"), true},
+		{NewGlob("a*b"), NewGlob("a*b*b"), true},
+		{NewGlob("a*b*b"), NewGlob("a*b"), false},
+		// '*' and '?' never match a newline, but a bracket expression can
+		{NewGlob("*"), NewGlob("[[:space:]]"), false},
+		{NewGlob("**", EditorConfigSyntax), NewGlob("src/**", EditorConfigSyntax), true},
+		// '**' matches everything that '*' does, including newlines
+		{NewGlob("**", EditorConfigSyntax), NewGlob("*", EditorConfigSyntax), true},
+		{NewGlob("a**b", EditorConfigSyntax), NewGlob("a*b", EditorConfigSyntax), true},
+		{NewGlob("a**b", EditorConfigSyntax), NewGlob("a?b", EditorConfigSyntax), true},
+		{NewGlob("*", EditorConfigSyntax), NewGlob("**", EditorConfigSyntax), false},
+		{NewGlob("*", EditorConfigSyntax), NewGlob("{a,b}*.go", EditorConfigSyntax), true},
+		{NewGlob("file{1..20}", EditorConfigSyntax), NewGlob("file[0-9]", EditorConfigSyntax), false},
+		{NewGlob("file{1..20}", EditorConfigSyntax), NewGlob("file1[1-9]", EditorConfigSyntax), true},
+		// a Glob that doesn't compile matches nothing
+		{NewGlob("*"), NewGlob("12345["), true},
+		{NewGlob("12345["), NewGlob("*"), false},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := Subsumes(testData.a, testData.b)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(
+			t,
+			testData.expectedResult,
+			actualResult,
+			"Subsumes(%q, %q)",
+			testData.a.Pattern(),
+			testData.b.Pattern(),
+		)
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		a               *Glob
+		b               *Glob
+		expectedResult  bool
+		expectedExample string
+	}{
+		{NewGlob("*.go"), NewGlob("main.*"), true, "main.go"},
+		{NewGlob("*.go"), NewGlob("*.md"), false, ""},
+		{NewGlob("a?c"), NewGlob("?b?"), true, "abc"},
+		{NewGlob("*"), NewGlob("*"), true, ""},
+		{NewGlob("[!a]"), NewGlob("[ab]"), true, "b"},
+		{NewGlob("[!a]"), NewGlob("a"), false, ""},
+		{NewGlob("*/*", EditorConfigSyntax), NewGlob("*.go", EditorConfigSyntax), false, ""},
+		{NewGlob("**", EditorConfigSyntax), NewGlob("*/*.go", EditorConfigSyntax), true, "/.go"},
+		{NewGlob("12345["), NewGlob("*"), false, ""},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult, actualExample := Overlaps(testData.a, testData.b)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedResult, actualResult, testData.a.Pattern())
+		assert.Equal(t, testData.expectedExample, actualExample, testData.a.Pattern())
+		if actualResult {
+			matchA, _ := testData.a.Match(actualExample)
+			matchB, _ := testData.b.Match(actualExample)
+			assert.True(t, matchA && matchB, actualExample)
+		}
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []relationTestDataStruct{
+		{NewGlob("*"), NewGlob("**"), true},
+		{NewGlob("a*"), NewGlob("a**"), true},
+		{NewGlob("*.go"), NewGlob("*.g?"), false},
+		{NewGlob("[ab]"), NewGlob("[ba]"), true},
+		{NewGlob("[ab]", EditorConfigSyntax), NewGlob("{a,b}", EditorConfigSyntax), true},
+		{NewGlob("file[1-3]", EditorConfigSyntax), NewGlob("file{1..3}", EditorConfigSyntax), false},
+		{NewGlob("12345["), NewGlob("[]"), true},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := Equivalent(testData.a, testData.b)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(
+			t,
+			testData.expectedResult,
+			actualResult,
+			"Equivalent(%q, %q)",
+			testData.a.Pattern(),
+			testData.b.Pattern(),
+		)
+	}
+}
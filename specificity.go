@@ -0,0 +1,227 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// Specificity scores how precisely a Glob's pattern picks out the
+// strings that it matches. Use it when the most specific of several
+// matching rules should win.
+//
+// Call `Glob.Specificity()` to create your Specificity, and
+// `Specificity.Compare()` to compare two of them.
+type Specificity struct {
+	// Literals is how many characters the pattern must match exactly
+	Literals int
+
+	// Classes is how many bracket expressions (such as `[a-z]`) and
+	// numeric ranges (such as `{1..10}`) the pattern uses
+	Classes int
+
+	// SingleWildcards is how many `?` wildcards the pattern uses
+	SingleWildcards int
+
+	// MultiWildcards is how many `*` wildcards the pattern uses
+	MultiWildcards int
+
+	// GlobStars is how many `**` wildcards the pattern uses
+	GlobStars int
+
+	// AnchoredStart is `true` if the pattern does not start with a `*`
+	// or `**` wildcard
+	AnchoredStart bool
+
+	// AnchoredEnd is `true` if the pattern does not end with a `*` or
+	// `**` wildcard
+	AnchoredEnd bool
+}
+
+// Specificity works out how specific the Glob's pattern is, from the
+// parts that the pattern was parsed into.
+//
+// A group of alternatives, such as `{a,bc}`, scores the same as its
+// least specific choice.
+func (g *Glob) Specificity() Specificity {
+	return scoreParts(g.patternParts)
+}
+
+// scoreParts works out the Specificity of a list of parsed patterns
+func scoreParts(parts []parsedPattern) Specificity {
+	retval := Specificity{AnchoredStart: true, AnchoredEnd: true}
+
+	for i, part := range parts {
+		score := scorePart(part)
+
+		retval.Literals += score.Literals
+		retval.Classes += score.Classes
+		retval.SingleWildcards += score.SingleWildcards
+		retval.MultiWildcards += score.MultiWildcards
+		retval.GlobStars += score.GlobStars
+
+		if i == 0 {
+			retval.AnchoredStart = score.AnchoredStart
+		}
+		if i == len(parts)-1 {
+			retval.AnchoredEnd = score.AnchoredEnd
+		}
+	}
+
+	return retval
+}
+
+// scorePart works out the Specificity of a single parsed pattern
+func scorePart(part parsedPattern) Specificity {
+	retval := Specificity{AnchoredStart: true, AnchoredEnd: true}
+
+	switch part.patternType {
+	case patternTypeStatic:
+		atoms, err := parseStaticAtoms(part.pattern)
+		if err != nil {
+			// the pattern won't compile; the best we can do is to
+			// treat every character as a literal
+			retval.Literals = utf8.RuneCountInString(part.pattern)
+			break
+		}
+		for _, atom := range atoms {
+			if atom.isLiteral() {
+				retval.Literals++
+			} else {
+				retval.Classes++
+			}
+		}
+	case patternTypeNumericRange:
+		retval.Classes = 1
+	case patternTypeSingleMatch, patternTypeSegmentSingleMatch:
+		retval.SingleWildcards = 1
+	case patternTypeMultiMatch, patternTypeSegmentMultiMatch:
+		retval.MultiWildcards = 1
+		retval.AnchoredStart = false
+		retval.AnchoredEnd = false
+	case patternTypeGlobStar:
+		retval.GlobStars = 1
+		retval.AnchoredStart = false
+		retval.AnchoredEnd = false
+	case patternTypeAlternatives:
+		for i, alternative := range part.alternatives {
+			score := scoreParts(alternative)
+			if i == 0 || score.Compare(retval) < 0 {
+				retval = score
+			}
+		}
+	}
+
+	return retval
+}
+
+// Compare returns a negative number if s is less specific than other,
+// zero if they are equally specific, and a positive number if s is
+// more specific than other.
+//
+// These rules are applied in order, until one of them decides:
+//
+//  1. more literal characters is more specific
+//  2. fewer `**` wildcards is more specific
+//  3. fewer `*` wildcards is more specific
+//  4. fewer `?` wildcards is more specific
+//  5. more bracket expressions and numeric ranges is more specific
+//  6. being anchored at both ends is more specific than being anchored
+//     at one end, which is more specific than being anchored at neither
+//  7. being anchored at the end (such as `*.example.com`) is more
+//     specific than being anchored at the start (such as `www.*`)
+func (s Specificity) Compare(other Specificity) int {
+	if s.Literals != other.Literals {
+		return s.Literals - other.Literals
+	}
+	if s.GlobStars != other.GlobStars {
+		return other.GlobStars - s.GlobStars
+	}
+	if s.MultiWildcards != other.MultiWildcards {
+		return other.MultiWildcards - s.MultiWildcards
+	}
+	if s.SingleWildcards != other.SingleWildcards {
+		return other.SingleWildcards - s.SingleWildcards
+	}
+	if s.Classes != other.Classes {
+		return s.Classes - other.Classes
+	}
+	if s.anchors() != other.anchors() {
+		return s.anchors() - other.anchors()
+	}
+	if s.AnchoredEnd != other.AnchoredEnd {
+		if s.AnchoredEnd {
+			return 1
+		}
+		return -1
+	}
+
+	return 0
+}
+
+// anchors returns how many ends of the pattern are anchored
+func (s Specificity) anchors() int {
+	retval := 0
+	if s.AnchoredStart {
+		retval++
+	}
+	if s.AnchoredEnd {
+		retval++
+	}
+
+	return retval
+}
+
+// SortBySpecificity sorts the given Globs so that the most specific
+// comes first, using `Specificity.Compare()`.
+//
+// Globs that are equally specific are sorted by their pattern text.
+// Globs with the same pattern text keep their original order.
+func SortBySpecificity(globs []*Glob) {
+	scores := make(map[*Glob]Specificity, len(globs))
+	for _, g := range globs {
+		scores[g] = g.Specificity()
+	}
+
+	sort.SliceStable(globs, func(i, j int) bool {
+		cmp := scores[globs[i]].Compare(scores[globs[j]])
+		if cmp != 0 {
+			return cmp > 0
+		}
+
+		return globs[i].pattern < globs[j].pattern
+	})
+}
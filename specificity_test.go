@@ -0,0 +1,215 @@
+// glob brings UNIX shell-like pattern matching support to Golang
+//
+// Copyright 2019-present Ganbaro Digital Ltd
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions
+// are met:
+//
+//   * Redistributions of source code must retain the above copyright
+//     notice, this list of conditions and the following disclaimer.
+//
+//   * Redistributions in binary form must reproduce the above copyright
+//     notice, this list of conditions and the following disclaimer in
+//     the documentation and/or other materials provided with the
+//     distribution.
+//
+//   * Neither the names of the copyright holders nor the names of his
+//     contributors may be used to endorse or promote products derived
+//     from this software without specific prior written permission.
+//
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+// "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+// LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+// FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE
+// COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+// CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT
+// LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN
+// ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+// POSSIBILITY OF SUCH DAMAGE.
+
+package glob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobSpecificityScoresParsedPattern(t *testing.T) {
+	t.Parallel()
+
+	testDataSet := []struct {
+		pattern        string
+		options        []func(*Glob)
+		expectedResult Specificity
+	}{
+		{
+			pattern:        "www.example.com",
+			expectedResult: Specificity{Literals: 15, AnchoredStart: true, AnchoredEnd: true},
+		},
+		{
+			pattern:        "*.example.com",
+			expectedResult: Specificity{Literals: 12, MultiWildcards: 1, AnchoredEnd: true},
+		},
+		{
+			pattern:        "www.example.*",
+			expectedResult: Specificity{Literals: 12, MultiWildcards: 1, AnchoredStart: true},
+		},
+		{
+			pattern:        "file?.[ch]",
+			expectedResult: Specificity{Literals: 5, Classes: 1, SingleWildcards: 1, AnchoredStart: true, AnchoredEnd: true},
+		},
+		{
+			pattern:        "\\*é*",
+			expectedResult: Specificity{Literals: 2, MultiWildcards: 1, AnchoredStart: true},
+		},
+		{
+			pattern:        "",
+			expectedResult: Specificity{AnchoredStart: true, AnchoredEnd: true},
+		},
+		{
+			pattern:        "src/**/*.go",
+			options:        []func(*Glob){EditorConfigSyntax},
+			expectedResult: Specificity{Literals: 6, MultiWildcards: 1, GlobStars: 1, AnchoredStart: true, AnchoredEnd: true},
+		},
+		{
+			pattern:        "*.{go,c}",
+			options:        []func(*Glob){EditorConfigSyntax},
+			expectedResult: Specificity{Literals: 2, MultiWildcards: 1, AnchoredEnd: true},
+		},
+		{
+			pattern:        "{a*,bcd}",
+			options:        []func(*Glob){EditorConfigSyntax},
+			expectedResult: Specificity{Literals: 1, MultiWildcards: 1, AnchoredStart: true},
+		},
+		{
+			pattern:        "v{1..10}",
+			options:        []func(*Glob){EditorConfigSyntax},
+			expectedResult: Specificity{Literals: 1, Classes: 1, AnchoredStart: true, AnchoredEnd: true},
+		},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		g := NewGlob(testData.pattern, testData.options...)
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		actualResult := g.Specificity()
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Equal(t, testData.expectedResult, actualResult, testData.pattern)
+	}
+}
+
+func TestSpecificityCompareAppliesRulesInOrder(t *testing.T) {
+	t.Parallel()
+
+	// each pair has the more specific pattern first
+	testDataSet := []struct {
+		morePattern string
+		lessPattern string
+		options     []func(*Glob)
+	}{
+		{morePattern: "abc*", lessPattern: "ab*"},
+		{morePattern: "a/*/b*", lessPattern: "a/**/b", options: []func(*Glob){EditorConfigSyntax}},
+		{morePattern: "a?b", lessPattern: "a*b"},
+		{morePattern: "a[bc]d", lessPattern: "a?d"},
+		{morePattern: "a[bc]*", lessPattern: "a*"},
+		{morePattern: "a*b", lessPattern: "*ab"},
+		{morePattern: "*ab", lessPattern: "ab*"},
+		{morePattern: "ab*", lessPattern: "*ab*"},
+	}
+
+	for _, testData := range testDataSet {
+		// ----------------------------------------------------------------
+		// setup your test
+
+		more := NewGlob(testData.morePattern, testData.options...).Specificity()
+		less := NewGlob(testData.lessPattern, testData.options...).Specificity()
+
+		// ----------------------------------------------------------------
+		// perform the change
+
+		moreVsLess := more.Compare(less)
+		lessVsMore := less.Compare(more)
+		moreVsMore := more.Compare(more)
+
+		// ----------------------------------------------------------------
+		// test the results
+
+		assert.Greater(t, moreVsLess, 0, testData.morePattern+" vs "+testData.lessPattern)
+		assert.Less(t, lessVsMore, 0, testData.lessPattern+" vs "+testData.morePattern)
+		assert.Equal(t, 0, moreVsMore, testData.morePattern)
+	}
+}
+
+func TestSortBySpecificityPutsMostSpecificFirst(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	globs := []*Glob{
+		NewGlob("*"),
+		NewGlob("mail.*"),
+		NewGlob("*.example.com"),
+		NewGlob("www.example.com"),
+		NewGlob("*.example.org"),
+		NewGlob("?.example.com"),
+	}
+	expectedResult := []string{
+		"www.example.com",
+		"?.example.com",
+		"*.example.com",
+		"*.example.org",
+		"mail.*",
+		"*",
+	}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	SortBySpecificity(globs)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	var actualResult []string
+	for _, g := range globs {
+		actualResult = append(actualResult, g.Pattern())
+	}
+	assert.Equal(t, expectedResult, actualResult)
+}
+
+func TestSortBySpecificityKeepsOrderOfIdenticalPatterns(t *testing.T) {
+	t.Parallel()
+
+	// ----------------------------------------------------------------
+	// setup your test
+
+	first := NewGlob("*.go")
+	second := NewGlob("*.go")
+	globs := []*Glob{NewGlob("*"), first, second}
+
+	// ----------------------------------------------------------------
+	// perform the change
+
+	SortBySpecificity(globs)
+
+	// ----------------------------------------------------------------
+	// test the results
+
+	assert.Same(t, first, globs[0])
+	assert.Same(t, second, globs[1])
+}